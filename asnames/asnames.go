@@ -0,0 +1,311 @@
+// Package asnames resolves AS numbers to names, organizations and countries
+// using local copies of the CAIDA AS to organization mapping and the delegated
+// statistics files of the RIRs.
+package asnames
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+)
+
+// Name is what is known about an AS. Only Asn is set for unknown ASes.
+type Name struct {
+	Asn          uint32 `json:"asn"`
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	Country      string `json:"country"`
+	Registry     string `json:"registry"`
+}
+
+// String returns the name of the AS, or its organization if there is none.
+func (n Name) String() string {
+	if n.Name != "" {
+		return n.Name
+	}
+	return n.Organization
+}
+
+// Registry holds the names of all ASes from the configured files. Supported
+// are the CAIDA as2org files, both the older text format (as-org2info.txt)
+// and JSON lines (as-org2info.jsonl), and RIR delegated statistics files
+// (delegated-<rir>-extended-latest), which only provide country and registry.
+// Names from CAIDA take precedence, the formats are detected per line.
+type Registry struct {
+	Files   []string `yaml:"files"`
+	Refresh int      `yaml:"refresh"` // seconds between reloads of the files, defaults to 86400
+
+	Logger log.RouteinfoLogger
+
+	lock  sync.RWMutex
+	names map[uint32]Name
+	stop  chan struct{}
+}
+
+// Start loads the configured files and reloads them in the background until
+// Stop is called.
+func (r *Registry) Start() error {
+	r.stop = make(chan struct{})
+	if len(r.Files) == 0 {
+		return nil
+	}
+	if err := r.LoadFiles(r.Files...); err != nil {
+		return err
+	}
+	refresh := time.Duration(r.Refresh) * time.Second
+	if refresh <= 0 {
+		refresh = 86400 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				if err := r.LoadFiles(r.Files...); err != nil {
+					r.logger().Errorf("Failed to reload AS name files: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *Registry) Stop() {
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+}
+
+// LoadFiles replaces the current names with those from the files.
+func (r *Registry) LoadFiles(filenames ...string) error {
+	l := newLoader()
+	for _, filename := range filenames {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		err = l.load(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("parsing AS name file %s: %w", filename, err)
+		}
+	}
+	names := l.names()
+	r.lock.Lock()
+	r.names = names
+	r.lock.Unlock()
+	r.logger().Infof("Loaded %d AS names from %s", len(names), strings.Join(filenames, ", "))
+	return nil
+}
+
+// Lookup returns what is known about an AS.
+func (r *Registry) Lookup(asn uint32) (Name, bool) {
+	if r == nil {
+		return Name{Asn: asn}, false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	name, ok := r.names[asn]
+	if !ok {
+		return Name{Asn: asn}, false
+	}
+	return name, true
+}
+
+// Names returns the names of all ASes in an AS path, or nil if no names are
+// loaded.
+func (r *Registry) Names(aspath []uint32) []Name {
+	if r == nil || len(aspath) == 0 {
+		return nil
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if len(r.names) == 0 {
+		return nil
+	}
+	names := make([]Name, len(aspath))
+	for i, asn := range aspath {
+		if name, ok := r.names[asn]; ok {
+			names[i] = name
+		} else {
+			names[i] = Name{Asn: asn}
+		}
+	}
+	return names
+}
+
+func (r *Registry) logger() log.ApplicationLogger {
+	if r.Logger == nil {
+		r.Logger = &log.DefaultRouteInfoLogger{}
+	}
+	return r.Logger.GetApplicationLogger()
+}
+
+type organization struct {
+	name    string
+	country string
+	source  string
+}
+
+type autonomousSystem struct {
+	name   string
+	org    string
+	source string
+}
+
+type delegation struct {
+	country  string
+	registry string
+}
+
+// loader collects the records of all files, as organizations and ASes of the
+// CAIDA files may be spread across several of them.
+type loader struct {
+	orgs        map[string]organization
+	ases        map[uint32]autonomousSystem
+	delegations map[uint32]delegation
+}
+
+func newLoader() *loader {
+	return &loader{
+		orgs:        make(map[string]organization),
+		ases:        make(map[uint32]autonomousSystem),
+		delegations: make(map[uint32]delegation),
+	}
+}
+
+func (l *loader) load(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	section := ""
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		var err error
+		switch {
+		case text == "":
+		case strings.HasPrefix(text, "#"):
+			// CAIDA text files announce their sections in comments
+			if strings.Contains(text, "format:org_id|") {
+				section = "org"
+			} else if strings.Contains(text, "format:aut|") {
+				section = "aut"
+			}
+		case strings.HasPrefix(text, "{"):
+			err = l.caidaJSON(text)
+		case section != "":
+			err = l.caidaText(section, strings.Split(text, "|"))
+		default:
+			err = l.delegated(strings.Split(text, "|"))
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func (l *loader) caidaText(section string, fields []string) error {
+	switch section {
+	case "org":
+		// org_id|changed|org_name|country|source
+		if len(fields) < 5 {
+			return fmt.Errorf("expected 5 fields in organization, got %d", len(fields))
+		}
+		l.orgs[fields[0]] = organization{name: fields[2], country: fields[3], source: fields[4]}
+	case "aut":
+		// aut|changed|aut_name|org_id|opaque_id|source
+		if len(fields) < 6 {
+			return fmt.Errorf("expected 6 fields in AS, got %d", len(fields))
+		}
+		asn, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		l.ases[uint32(asn)] = autonomousSystem{name: fields[2], org: fields[3], source: fields[5]}
+	}
+	return nil
+}
+
+func (l *loader) caidaJSON(text string) error {
+	var record struct {
+		Type           string `json:"type"`
+		Asn            string `json:"asn"`
+		Name           string `json:"name"`
+		OrganizationId string `json:"organizationId"`
+		Country        string `json:"country"`
+		Source         string `json:"source"`
+	}
+	if err := json.Unmarshal([]byte(text), &record); err != nil {
+		return err
+	}
+	switch record.Type {
+	case "Organization":
+		l.orgs[record.OrganizationId] = organization{name: record.Name, country: record.Country, source: record.Source}
+	case "ASN":
+		asn, err := strconv.ParseUint(record.Asn, 10, 32)
+		if err != nil {
+			return err
+		}
+		l.ases[uint32(asn)] = autonomousSystem{name: record.Name, org: record.OrganizationId, source: record.Source}
+	}
+	return nil
+}
+
+// delegated parses a line of a delegated statistics file, i.e.
+// registry|cc|type|start|value|date|status[|opaque-id], ignoring the version
+// and summary lines and all delegations of address space.
+func (l *loader) delegated(fields []string) error {
+	if len(fields) < 7 || fields[2] != "asn" || fields[1] == "*" {
+		return nil
+	}
+	start, err := strconv.ParseUint(fields[3], 10, 32)
+	if err != nil {
+		return err
+	}
+	count, err := strconv.ParseUint(fields[4], 10, 32)
+	if err != nil {
+		return err
+	}
+	if fields[6] != "allocated" && fields[6] != "assigned" {
+		return nil
+	}
+	for asn := start; asn < start+count && asn <= 0xffffffff; asn++ {
+		l.delegations[uint32(asn)] = delegation{country: fields[1], registry: fields[0]}
+	}
+	return nil
+}
+
+func (l *loader) names() map[uint32]Name {
+	names := make(map[uint32]Name, len(l.ases)+len(l.delegations))
+	for asn, d := range l.delegations {
+		names[asn] = Name{Asn: asn, Country: d.country, Registry: d.registry}
+	}
+	for asn, as := range l.ases {
+		name := names[asn]
+		name.Asn = asn
+		name.Name = as.name
+		if org, ok := l.orgs[as.org]; ok {
+			name.Organization = org.name
+			if org.country != "" {
+				name.Country = org.country
+			}
+		}
+		if name.Registry == "" {
+			name.Registry = as.source
+		}
+		names[asn] = name
+	}
+	return names
+}
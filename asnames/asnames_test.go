@@ -0,0 +1,84 @@
+package asnames
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const caidaText = `# name: AS Org
+# format:org_id|changed|org_name|country|source
+EXAMPLE-ARIN|20240101|Example Networks, Inc.|US|ARIN
+# format:aut|changed|aut_name|org_id|opaque_id|source
+64500|20240101|EXAMPLE-NET|EXAMPLE-ARIN|0123456789abcdef_ARIN|ARIN
+`
+
+const caidaJSON = `{"changed":"20240101","country":"DE","name":"Example GmbH","organizationId":"ORG-EX1-RIPE","source":"RIPE","type":"Organization"}
+{"asn":"64501","changed":"20240101","name":"EXAMPLE-DE","opaqueId":"0123456789abcdef_RIPE","organizationId":"ORG-EX1-RIPE","source":"RIPE","type":"ASN"}
+`
+
+const delegated = `2|ripencc|20240101|3|19830705|20240101|+0100
+ripencc|*|asn|*|3|summary
+ripencc|DE|asn|64501|1|20240101|allocated|0123456789abcdef
+ripencc|FR|asn|64510|2|20240101|assigned|0123456789abcdef
+ripencc|NL|ipv4|192.0.2.0|256|20240101|allocated|0123456789abcdef
+ripencc||asn|64520|1||available|
+`
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	var files []string
+	for name, content := range map[string]string{"as-org2info.txt": caidaText, "as-org2info.jsonl": caidaJSON, "delegated": delegated} {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	var registry Registry
+	if err := registry.LoadFiles(files...); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []Name{
+		{Asn: 64500, Name: "EXAMPLE-NET", Organization: "Example Networks, Inc.", Country: "US", Registry: "ARIN"},
+		{Asn: 64501, Name: "EXAMPLE-DE", Organization: "Example GmbH", Country: "DE", Registry: "ripencc"},
+		{Asn: 64511, Country: "FR", Registry: "ripencc"},
+	} {
+		if got, ok := registry.Lookup(want.Asn); !ok || got != want {
+			t.Errorf("got %+v for AS%d, want %+v", got, want.Asn, want)
+		}
+	}
+	if _, ok := registry.Lookup(64520); ok {
+		t.Error("available AS has a name")
+	}
+
+	names := registry.Names([]uint32{64500, 64520})
+	if len(names) != 2 || names[0].String() != "EXAMPLE-NET" || names[1] != (Name{Asn: 64520}) {
+		t.Errorf("unexpected names %+v", names)
+	}
+
+	var unloaded *Registry
+	if names := unloaded.Names([]uint32{64500}); names != nil {
+		t.Errorf("got names %+v without registry", names)
+	}
+	if name, ok := unloaded.Lookup(64500); ok || name != (Name{Asn: 64500}) {
+		t.Errorf("got %+v without registry", name)
+	}
+}
+
+func TestRegistryInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "as-org2info.txt")
+	content := "# format:aut|changed|aut_name|org_id|opaque_id|source\nAS64500|20240101|EXAMPLE|EXAMPLE-ARIN|x|ARIN\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var registry Registry
+	err := registry.LoadFiles(file)
+	if err == nil {
+		t.Fatal("invalid file did not fail")
+	}
+	if registry.names != nil {
+		t.Error("names were replaced by an invalid file")
+	}
+}
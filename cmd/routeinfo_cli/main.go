@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+const usage = `Usage: routeinfo_cli [flags] <command> [arguments]
+
+Commands:
+  lookup <prefix>   show the paths of a prefix or address
+  status            show whether the routers are ready
+  neighbors         show the sessions of the routers
+  compare <prefix>  compare the best paths of a prefix on the routers
+  watch             print events until interrupted
+
+Flags:
+`
+
+// The responses of the HTTP API, as defined by routeinfo_server.
+
+type PrefixResult struct {
+	Router      string                   `json:"router"`
+	Prefix      string                   `json:"prefix"`
+	Paths       []routeinfo.RouteInfo    `json:"paths"`
+	Explanation []routeinfo.PathDecision `json:"explanation,omitempty"`
+}
+
+type PrefixResponse struct {
+	Errors  []string       `json:"errors"`
+	Results []PrefixResult `json:"results"`
+}
+
+type RouterStatus struct {
+	Router string `json:"router"`
+	Ready  bool   `json:"ready"`
+}
+
+type StatusResponse struct {
+	Errors  []string       `json:"errors"`
+	Results []RouterStatus `json:"results"`
+}
+
+type NeighborsResult struct {
+	Router    string                     `json:"router"`
+	Neighbors []routeinfo.NeighborStatus `json:"neighbors"`
+}
+
+type NeighborsResponse struct {
+	Errors  []string          `json:"errors"`
+	Results []NeighborsResult `json:"results"`
+}
+
+type CompareResponse struct {
+	Errors []string                    `json:"errors"`
+	Result *routeinfo.PrefixComparison `json:"result"`
+}
+
+// stringList is a flag which may be given multiple times.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// commandFlags tells which commands support the flags specific to some of
+// them.
+var commandFlags = map[string]map[string]bool{
+	"lookup":    {"r": true, "t": false, "explain": true},
+	"status":    {"r": false, "t": false, "explain": false},
+	"neighbors": {"r": true, "t": false, "explain": false},
+	"compare":   {"r": true, "t": false, "explain": false},
+	"watch":     {"r": true, "t": true, "explain": false},
+}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+func main() {
+	var routers, types stringList
+	server := flag.String("s", "http://localhost:3000", "URL of the routeinfo_server HTTP API")
+	format := flag.String("o", "table", "Output format: one of 'table', 'json' or 'text'")
+	colorMode := flag.String("color", "auto", "Colorize the output: one of 'auto', 'always' or 'never'")
+	explain := flag.Bool("explain", false, "lookup: explain why paths are not the best path")
+	flag.Var(&routers, "r", "Router to query, may be given multiple times, defaults to all routers")
+	flag.Var(&types, "t", "watch: type of events to print, may be given multiple times, defaults to all types")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	switch *format {
+	case "table", "json", "text":
+	default:
+		fatalf("unknown output format %q", *format)
+	}
+	switch *colorMode {
+	case "always":
+		color = true
+	case "auto":
+		stat, err := os.Stdout.Stat()
+		color = err == nil && stat.Mode()&os.ModeCharDevice != 0 && os.Getenv("NO_COLOR") == ""
+	case "never":
+	default:
+		fatalf("unknown color mode %q", *colorMode)
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if commands, ok := commandFlags[args[0]]; ok {
+		flag.Visit(func(f *flag.Flag) {
+			if supported, ok := commands[f.Name]; ok && !supported {
+				fatalf("%s does not support -%s", args[0], f.Name)
+			}
+		})
+	}
+	base := strings.TrimSuffix(*server, "/")
+	switch args[0] {
+	case "lookup":
+		if len(args) != 2 {
+			fatalf("lookup needs exactly one prefix")
+		}
+		query := url.Values{"prefix": {args[1]}, "router": routers}
+		if *explain {
+			query.Set("explain", "true")
+		}
+		var response PrefixResponse
+		get(base+"/prefix?"+query.Encode(), &response)
+		printErrors(response.Errors)
+		slices.SortFunc(response.Results, func(a, b PrefixResult) int { return strings.Compare(a.Router, b.Router) })
+		output(*format, response, func() { printLookup(os.Stdout, *format, response.Results) })
+	case "status":
+		var response StatusResponse
+		get(base+"/status", &response)
+		printErrors(response.Errors)
+		slices.SortFunc(response.Results, func(a, b RouterStatus) int { return strings.Compare(a.Router, b.Router) })
+		output(*format, response, func() { printStatus(os.Stdout, response.Results) })
+	case "neighbors":
+		var response NeighborsResponse
+		get(base+"/neighbors?"+url.Values{"router": routers}.Encode(), &response)
+		printErrors(response.Errors)
+		slices.SortFunc(response.Results, func(a, b NeighborsResult) int { return strings.Compare(a.Router, b.Router) })
+		output(*format, response, func() { printNeighbors(os.Stdout, response.Results) })
+	case "compare":
+		if len(args) != 2 {
+			fatalf("compare needs exactly one prefix")
+		}
+		query := url.Values{"prefix": {args[1]}}
+		if len(routers) > 0 {
+			query.Set("routers", strings.Join(routers, ","))
+		}
+		var response CompareResponse
+		get(base+"/compare?"+query.Encode(), &response)
+		printErrors(response.Errors)
+		if response.Result == nil {
+			os.Exit(1)
+		}
+		output(*format, response, func() { printComparison(os.Stdout, *format, response.Result) })
+	case "watch":
+		watch(base+"/events", *format, routers, types)
+	default:
+		fatalf("unknown command %q", args[0])
+	}
+}
+
+// get decodes the JSON response to a GET request.
+func get(address string, response any) {
+	resp, err := client.Get(address)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fatalf("%s: %s", address, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		fatalf("decoding response of %s: %v", address, err)
+	}
+}
+
+// watch prints the events streamed by the server until it closes the
+// connection.
+func watch(address string, format string, routers []string, types []string) {
+	resp, err := (&http.Client{}).Get(address)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fatalf("%s: %s", address, resp.Status)
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event routeinfo.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			fatalf("decoding event: %v", err)
+		}
+		if len(routers) > 0 && !slices.Contains(routers, event.Router) {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, string(event.Type)) {
+			continue
+		}
+		if format == "json" {
+			fmt.Println(scanner.Text())
+		} else {
+			printEvent(os.Stdout, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fatalf("%v", err)
+	}
+}
+
+// output prints the response as JSON, or using print for the other formats.
+func output(format string, response any, print func()) {
+	if format != "json" {
+		print()
+		return
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(response); err != nil {
+		fatalf("%v", err)
+	}
+}
+
+func printErrors(errors []string) {
+	for _, err := range errors {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "routeinfo_cli: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorBold   = "\033[1m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+// color is whether the output is colorized.
+var color bool
+
+// now is the time the ages of paths are relative to.
+var now = time.Now
+
+func colorize(code string, s string) string {
+	if !color || s == "" {
+		return s
+	}
+	return code + s + colorReset
+}
+
+func rpki(state bgp.ValidationState) string {
+	switch state {
+	case bgp.VALIDATION_STATE_VALID:
+		return colorize(colorGreen, state.String())
+	case bgp.VALIDATION_STATE_INVALID:
+		return colorize(colorRed, state.String())
+	default:
+		return colorize(colorYellow, state.String())
+	}
+}
+
+func asPath(path []uint32) string {
+	hops := make([]string, len(path))
+	for i, asn := range path {
+		hops[i] = strconv.FormatUint(uint64(asn), 10)
+	}
+	return strings.Join(hops, " ")
+}
+
+// newTable returns a tabwriter for the output. Colorized cells are aligned
+// correctly as all cells in a column contain the same escape codes, if any.
+func newTable(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+}
+
+func printLookup(out io.Writer, format string, results []PrefixResult) {
+	if format == "text" {
+		for _, result := range results {
+			printShowRoute(out, result)
+		}
+		return
+	}
+	table := newTable(out)
+	fmt.Fprintln(table, "ROUTER\tPREFIX\tBEST\tAS PATH\tNEXT HOP\tLOCPRF\tMED\tORIGIN\tRPKI\tCOMMUNITIES")
+	for _, result := range results {
+		for _, path := range result.Paths {
+			best := colorize(colorGreen, " ")
+			if path.Best {
+				best = colorize(colorGreen, "*")
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+				result.Router, path.Prefix, best, asPath(path.AsPath), path.NextHop, path.LocalPref, path.Med,
+				path.Origin, rpki(path.Validation), strings.Join(slices.Concat(path.Communities, path.LargeCommunities), " "))
+		}
+	}
+	table.Flush()
+	for _, result := range results {
+		for _, decision := range result.Explanation {
+			fmt.Fprintf(out, "%s: path via %s lost at %s: %s\n", result.Router, decision.Path.NextHop, decision.LostAt, decision.Reason)
+		}
+	}
+}
+
+// printShowRoute prints the paths of a prefix like "show route" on a router.
+func printShowRoute(out io.Writer, result PrefixResult) {
+	fmt.Fprintf(out, "%s: %s, %d paths\n", colorize(colorBold, result.Router), result.Prefix, len(result.Paths))
+	for _, path := range result.Paths {
+		marker := " "
+		if path.Best {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s[BGP] from %s via %s", marker, path.Peer, path.NextHop)
+		if path.Best {
+			line = colorize(colorGreen, line)
+		}
+		fmt.Fprintf(out, "  %s, %s ago\n", line, now().Sub(path.Timestamp).Round(time.Second))
+		fmt.Fprintf(out, "      AS path: %s %s\n", asPath(path.AsPath), path.Origin)
+		fmt.Fprintf(out, "      Local pref: %d, MED: %d\n", path.LocalPref, path.Med)
+		if len(path.Communities) > 0 {
+			fmt.Fprintf(out, "      Communities: %s\n", strings.Join(path.Communities, " "))
+		}
+		if len(path.LargeCommunities) > 0 {
+			fmt.Fprintf(out, "      Large communities: %s\n", strings.Join(path.LargeCommunities, " "))
+		}
+		if len(path.ExtendedCommunities) > 0 {
+			fmt.Fprintf(out, "      Extended communities: %s\n", strings.Join(path.ExtendedCommunities, " "))
+		}
+		fmt.Fprintf(out, "      RPKI: %s\n", rpki(path.Validation))
+	}
+	for _, decision := range result.Explanation {
+		fmt.Fprintf(out, "  path via %s lost at %s: %s\n", decision.Path.NextHop, decision.LostAt, decision.Reason)
+	}
+}
+
+func printStatus(out io.Writer, results []RouterStatus) {
+	table := newTable(out)
+	fmt.Fprintln(table, "ROUTER\tREADY")
+	for _, result := range results {
+		ready := colorize(colorRed, "no")
+		if result.Ready {
+			ready = colorize(colorGreen, "yes")
+		}
+		fmt.Fprintf(table, "%s\t%s\n", result.Router, ready)
+	}
+	table.Flush()
+}
+
+func printNeighbors(out io.Writer, results []NeighborsResult) {
+	table := newTable(out)
+	fmt.Fprintln(table, "ROUTER\tNEIGHBOR\tASN\tSTATE\tSINCE\tRECEIVED\tACCEPTED")
+	for _, result := range results {
+		for _, neighbor := range result.Neighbors {
+			state := colorize(colorRed, neighbor.State)
+			if neighbor.State == "established" {
+				state = colorize(colorGreen, neighbor.State)
+			}
+			since := ""
+			if !neighbor.Since.IsZero() {
+				since = neighbor.Since.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%d\t%d\n",
+				result.Router, neighbor.Address, neighbor.Asn, state, since, neighbor.Received, neighbor.Accepted)
+		}
+	}
+	table.Flush()
+}
+
+func printComparison(out io.Writer, format string, comparison *routeinfo.PrefixComparison) {
+	if format == "text" {
+		for _, group := range comparison.Groups {
+			printShowRoute(out, PrefixResult{
+				Router: strings.Join(group.Routers, ", "),
+				Prefix: group.Best.Prefix,
+				Paths:  []routeinfo.RouteInfo{group.Best},
+			})
+		}
+	} else {
+		table := newTable(out)
+		fmt.Fprintln(table, "ROUTERS\tPREFIX\tAS PATH\tNEXT HOP\tLOCPRF\tMED\tRPKI\tDIFFERENCES")
+		for _, group := range comparison.Groups {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+				strings.Join(group.Routers, ","), group.Best.Prefix, asPath(group.Best.AsPath), group.Best.NextHop,
+				group.Best.LocalPref, group.Best.Med, rpki(group.Best.Validation), strings.Join(group.Differences, ","))
+		}
+		table.Flush()
+	}
+	if len(comparison.Missing) > 0 {
+		fmt.Fprintln(out, colorize(colorRed, "No best path on: "+strings.Join(comparison.Missing, ", ")))
+	}
+	if len(comparison.Groups) > 1 {
+		fmt.Fprintln(out, colorize(colorYellow, "Best paths differ in: "+strings.Join(comparison.Differences, ", ")))
+	}
+}
+
+func printEvent(out io.Writer, event routeinfo.Event) {
+	kind := string(event.Type)
+	switch event.Type {
+	case routeinfo.EventBestPathChanged:
+		kind = colorize(colorYellow, kind)
+	default:
+		kind = colorize(colorRed, kind)
+	}
+	fmt.Fprintf(out, "%s %s %s %s %s\n", event.Time.Local().Format(time.DateTime), event.Router, kind, event.Prefix, event.Message)
+}
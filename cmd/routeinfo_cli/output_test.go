@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func testLookupResults() []PrefixResult {
+	learned := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	best := routeinfo.RouteInfo{
+		Prefix:           "192.0.2.0/24",
+		Peer:             "198.51.100.1",
+		NextHop:          "198.51.100.1",
+		AsPath:           []uint32{64500, 64501},
+		LocalPref:        200,
+		Best:             true,
+		Communities:      []string{"64500:1"},
+		LargeCommunities: []string{"64500:1:2"},
+		Timestamp:        learned,
+		Validation:       bgp.VALIDATION_STATE_VALID,
+	}
+	other := routeinfo.RouteInfo{
+		Prefix:              "192.0.2.0/24",
+		Peer:                "198.51.100.2",
+		NextHop:             "198.51.100.2",
+		AsPath:              []uint32{64502, 64503, 64501},
+		LocalPref:           100,
+		Med:                 10,
+		Origin:              routeinfo.Incomplete,
+		Communities:         []string{"64502:1"},
+		ExtendedCommunities: []string{"rt:64500:3"},
+		Timestamp:           learned.Add(-time.Hour),
+		Validation:          bgp.VALIDATION_STATE_INVALID,
+	}
+	return []PrefixResult{
+		{
+			Router:      "rt-1",
+			Prefix:      "192.0.2.0/24",
+			Paths:       []routeinfo.RouteInfo{best, other},
+			Explanation: []routeinfo.PathDecision{{Path: other, LostAt: routeinfo.StepLocalPref, Reason: "local-pref 100 is lower than 200"}},
+		},
+		{
+			Router: "rt-2",
+			Prefix: "192.0.2.0/24",
+			Paths:  []routeinfo.RouteInfo{best},
+		},
+	}
+}
+
+func TestPrintLookup(t *testing.T) {
+	defer func(previous func() time.Time) { now = previous }(now)
+	now = func() time.Time { return time.Date(2024, 1, 2, 4, 4, 5, 0, time.UTC) }
+	defer func(previous bool) { color = previous }(color)
+
+	for _, test := range []struct {
+		golden string
+		format string
+		color  bool
+	}{
+		{"lookup_table.golden", "table", false},
+		{"lookup_table_color.golden", "table", true},
+		{"lookup_text.golden", "text", false},
+		{"lookup_text_color.golden", "text", true},
+	} {
+		color = test.color
+		var out bytes.Buffer
+		printLookup(&out, test.format, testLookupResults())
+
+		golden := filepath.Join("testdata", test.golden)
+		if *update {
+			if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.golden, out.Bytes(), want)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// The birdwatcher API (https://github.com/alice-lg/birdwatcher) is served for
+// each router below /birdwatcher/<router>/, so it can be used as a route server
+// source of type birdwatcher with "single_table" mode in Alice-LG. Protocols
+// are the sessions of the router to us.
+
+// birdwatcherTime is the time format used by birdwatcher.
+const birdwatcherTime = "2006-01-02 15:04:05"
+
+// birdwatcherCacheTTL is how long the routes of the protocols are cached and
+// clients are told to cache responses.
+const birdwatcherCacheTTL = 5 * time.Minute
+
+var started = time.Now()
+
+type BirdwatcherCacheStatus struct {
+	CachedAt struct {
+		Date         string `json:"date"`
+		TimezoneType string `json:"timezone_type"`
+		Timezone     string `json:"timezone"`
+	} `json:"cached_at"`
+	OrigTTL int `json:"orig_ttl"`
+}
+
+type BirdwatcherAPI struct {
+	Version         string                 `json:"Version"`
+	ResultFromCache bool                   `json:"result_from_cache"`
+	CacheStatus     BirdwatcherCacheStatus `json:"cache_status"`
+}
+
+type BirdwatcherResponse struct {
+	API      BirdwatcherAPI `json:"api"`
+	CachedAt string         `json:"cached_at"`
+	TTL      string         `json:"ttl"`
+}
+
+type BirdwatcherStatus struct {
+	BirdwatcherResponse
+	Status struct {
+		CurrentServer string `json:"current_server"`
+		LastReboot    string `json:"last_reboot"`
+		LastReconfig  string `json:"last_reconfig"`
+		Message       string `json:"message"`
+		RouterID      string `json:"router_id"`
+		Version       string `json:"version"`
+	} `json:"status"`
+}
+
+type BirdwatcherRouteCounts struct {
+	Imported  uint64 `json:"imported"`
+	Filtered  uint64 `json:"filtered"`
+	Exported  uint64 `json:"exported"`
+	Preferred uint64 `json:"preferred"`
+}
+
+type BirdwatcherProtocol struct {
+	BirdProtocol    string                 `json:"bird_protocol"`
+	Connection      string                 `json:"connection"`
+	Description     string                 `json:"description"`
+	NeighborAddress string                 `json:"neighbor_address"`
+	NeighborAs      uint32                 `json:"neighbor_as"`
+	Routes          BirdwatcherRouteCounts `json:"routes"`
+	State           string                 `json:"state"`
+	StateChanged    string                 `json:"state_changed"`
+	Table           string                 `json:"table"`
+}
+
+type BirdwatcherProtocols struct {
+	BirdwatcherResponse
+	Protocols map[string]BirdwatcherProtocol `json:"protocols"`
+}
+
+type BirdwatcherBGP struct {
+	AsPath           []string   `json:"as_path"`
+	Communities      [][]uint32 `json:"communities"`
+	ExtCommunities   [][]string `json:"ext_communities"`
+	LargeCommunities [][]uint32 `json:"large_communities"`
+	LocalPref        string     `json:"local_pref"`
+	Med              string     `json:"med"`
+	NextHop          string     `json:"next_hop"`
+	Origin           string     `json:"origin"`
+}
+
+// BirdwatcherRoute is a path as shown by bird. Metric is the metric of bird,
+// which we do not know, so it is always 0.
+type BirdwatcherRoute struct {
+	Age          string         `json:"age"`
+	BGP          BirdwatcherBGP `json:"bgp"`
+	FromProtocol string         `json:"from_protocol"`
+	Gateway      string         `json:"gateway"`
+	Interface    string         `json:"interface"`
+	LearntFrom   string         `json:"learnt_from"`
+	Metric       int            `json:"metric"`
+	Network      string         `json:"network"`
+	Primary      bool           `json:"primary"`
+	Type         []string       `json:"type"`
+}
+
+type BirdwatcherRoutes struct {
+	BirdwatcherResponse
+	Routes []BirdwatcherRoute `json:"routes"`
+}
+
+// newBirdwatcherResponse returns the header of a response with data from the
+// given time, which may be cached until birdwatcherCacheTTL later.
+func newBirdwatcherResponse(cachedAt time.Time) BirdwatcherResponse {
+	cachedAt = cachedAt.UTC()
+	response := BirdwatcherResponse{
+		API:      BirdwatcherAPI{Version: "routeinfo"},
+		CachedAt: cachedAt.Format(time.RFC3339Nano),
+		TTL:      cachedAt.Add(birdwatcherCacheTTL).Format(time.RFC3339Nano),
+	}
+	response.API.CacheStatus.CachedAt.Date = cachedAt.Format(time.RFC3339Nano)
+	response.API.CacheStatus.CachedAt.Timezone = "UTC"
+	response.API.CacheStatus.OrigTTL = int(birdwatcherCacheTTL.Seconds())
+	return response
+}
+
+// birdwatcherPeerRoutes caches the paths of each router by the peer they were
+// received from, so Alice-LG refreshing all protocols walks each RIB once per
+// birdwatcherCacheTTL instead of once per protocol. Entries are dropped after
+// birdwatcherCacheTTL, so the copies of the RIBs are not kept when unused.
+var birdwatcherPeerRoutes struct {
+	lock    sync.Mutex
+	routers map[string]*peerRoutes
+}
+
+type peerRoutes struct {
+	once     sync.Once
+	cachedAt time.Time
+	paths    map[string][]routeinfo.RouteInfo
+}
+
+// routesByPeer returns the paths of a router by peer address, and whether they
+// were cached by an earlier request.
+func routesByPeer(name string, router *routeinfo.Router) (*peerRoutes, bool) {
+	birdwatcherPeerRoutes.lock.Lock()
+	if birdwatcherPeerRoutes.routers == nil {
+		birdwatcherPeerRoutes.routers = make(map[string]*peerRoutes)
+	}
+	routes := birdwatcherPeerRoutes.routers[name]
+	cached := routes != nil
+	if !cached {
+		routes = &peerRoutes{cachedAt: time.Now()}
+		birdwatcherPeerRoutes.routers[name] = routes
+		time.AfterFunc(birdwatcherCacheTTL, func() {
+			birdwatcherPeerRoutes.lock.Lock()
+			defer birdwatcherPeerRoutes.lock.Unlock()
+			if birdwatcherPeerRoutes.routers[name] == routes {
+				delete(birdwatcherPeerRoutes.routers, name)
+			}
+		})
+	}
+	birdwatcherPeerRoutes.lock.Unlock()
+
+	// concurrent requests wait for the same walk
+	routes.once.Do(func() {
+		routes.paths = make(map[string][]routeinfo.RouteInfo)
+		router.Walk(func(prefix string, paths []routeinfo.RouteInfo) {
+			for _, path := range paths {
+				routes.paths[path.Peer] = append(routes.paths[path.Peer], path)
+			}
+		})
+	})
+	return routes, cached
+}
+
+// birdwatcherProtocolID returns the protocol name of the session to a
+// neighbor, i.e. "R192_0_2_1" for 192.0.2.1.
+func birdwatcherProtocolID(address string) string {
+	return "R" + strings.NewReplacer(".", "_", ":", "_").Replace(address)
+}
+
+// birdwatcherRouter returns the router given in the path, or writes a 404.
+func birdwatcherRouter(writer http.ResponseWriter, request *http.Request) (*routeinfo.Router, bool) {
+	router, ok := rs.Routers[request.PathValue("router")]
+	if !ok {
+		http.Error(writer, "Router not found.", http.StatusNotFound)
+	}
+	return router, ok
+}
+
+func birdwatcherStatus(writer http.ResponseWriter, request *http.Request) {
+	router, ok := birdwatcherRouter(writer, request)
+	if !ok {
+		return
+	}
+	response := BirdwatcherStatus{BirdwatcherResponse: newBirdwatcherResponse(time.Now())}
+	response.Status.CurrentServer = time.Now().Format(birdwatcherTime)
+	response.Status.LastReboot = started.Format(birdwatcherTime)
+	response.Status.LastReconfig = started.Format(birdwatcherTime)
+	response.Status.RouterID = rs.RouterId
+	response.Status.Version = "routeinfo"
+	if router.Established() {
+		response.Status.Message = "Daemon is up and running"
+	} else {
+		response.Status.Message = "Sessions to the router are down"
+	}
+	writeJSON(writer, response)
+}
+
+func birdwatcherProtocols(writer http.ResponseWriter, request *http.Request) {
+	router, ok := birdwatcherRouter(writer, request)
+	if !ok {
+		return
+	}
+	response := BirdwatcherProtocols{
+		BirdwatcherResponse: newBirdwatcherResponse(time.Now()),
+		Protocols:           make(map[string]BirdwatcherProtocol),
+	}
+	for _, neighbor := range router.NeighborStatus() {
+		id := birdwatcherProtocolID(neighbor.Address)
+		protocol := BirdwatcherProtocol{
+			BirdProtocol:    "BGP",
+			Connection:      "Established",
+			Description:     router.Name,
+			NeighborAddress: neighbor.Address,
+			NeighborAs:      neighbor.Asn,
+			Routes: BirdwatcherRouteCounts{
+				Imported: neighbor.Accepted,
+				Filtered: neighbor.Received - min(neighbor.Accepted, neighbor.Received),
+			},
+			State: "up",
+			Table: "master",
+		}
+		if neighbor.State != "established" {
+			protocol.Connection = neighbor.State
+			protocol.State = "start"
+		}
+		if !neighbor.Since.IsZero() {
+			protocol.StateChanged = neighbor.Since.Format(birdwatcherTime)
+		}
+		response.Protocols[id] = protocol
+	}
+	writeJSON(writer, response)
+}
+
+func birdwatcherRoutesProtocol(writer http.ResponseWriter, request *http.Request) {
+	router, ok := birdwatcherRouter(writer, request)
+	if !ok {
+		return
+	}
+	id := request.PathValue("id")
+	for _, address := range router.Neighbors {
+		// paths and protocols use the canonical form of the address, which
+		// may differ from the configured one, i.e. "2001:DB8::1"
+		if addr, err := netip.ParseAddr(address); err == nil {
+			address = addr.String()
+		}
+		if birdwatcherProtocolID(address) != id {
+			continue
+		}
+		routes, cached := routesByPeer(request.PathValue("router"), router)
+		response := newBirdwatcherRoutes(routes.paths[address])
+		response.BirdwatcherResponse = newBirdwatcherResponse(routes.cachedAt)
+		response.API.ResultFromCache = cached
+		writeJSON(writer, response)
+		return
+	}
+	http.Error(writer, "Protocol not found.", http.StatusNotFound)
+}
+
+// birdwatcherNoRoutes answers the requests for filtered and not exported
+// routes, which we do not know about.
+func birdwatcherNoRoutes(writer http.ResponseWriter, request *http.Request) {
+	if _, ok := birdwatcherRouter(writer, request); !ok {
+		return
+	}
+	writeJSON(writer, newBirdwatcherRoutes(nil))
+}
+
+func birdwatcherRoutesPrefix(writer http.ResponseWriter, request *http.Request) {
+	router, ok := birdwatcherRouter(writer, request)
+	if !ok {
+		return
+	}
+	writeJSON(writer, newBirdwatcherRoutes(router.Lookup(request.URL.Query().Get("prefix"))))
+}
+
+func newBirdwatcherRoutes(paths []routeinfo.RouteInfo) BirdwatcherRoutes {
+	response := BirdwatcherRoutes{
+		BirdwatcherResponse: newBirdwatcherResponse(time.Now()),
+		Routes:              []BirdwatcherRoute{},
+	}
+	for _, path := range paths {
+		route := BirdwatcherRoute{
+			Age:          path.Timestamp.Format(birdwatcherTime),
+			FromProtocol: birdwatcherProtocolID(path.Peer),
+			Gateway:      path.NextHop,
+			LearntFrom:   path.Peer,
+			Network:      path.Prefix,
+			Primary:      path.Best,
+			Type:         []string{"BGP", "unicast", "univ"},
+			BGP: BirdwatcherBGP{
+				AsPath:           []string{},
+				Communities:      [][]uint32{},
+				ExtCommunities:   [][]string{},
+				LargeCommunities: [][]uint32{},
+				LocalPref:        strconv.FormatUint(uint64(path.LocalPref), 10),
+				Med:              strconv.FormatUint(uint64(path.Med), 10),
+				NextHop:          path.NextHop,
+				Origin:           path.Origin.String(),
+			},
+		}
+		for _, asn := range path.AsPath {
+			route.BGP.AsPath = append(route.BGP.AsPath, strconv.FormatUint(uint64(asn), 10))
+		}
+		for _, community := range path.Communities {
+			if values, ok := birdwatcherCommunity(community, 2); ok {
+				route.BGP.Communities = append(route.BGP.Communities, values)
+			}
+		}
+		for _, community := range path.LargeCommunities {
+			if values, ok := birdwatcherCommunity(community, 3); ok {
+				route.BGP.LargeCommunities = append(route.BGP.LargeCommunities, values)
+			}
+		}
+		for _, community := range path.ExtendedCommunities {
+			// bird calls route origins "ro"
+			fields := strings.SplitN(strings.Replace(community, "soo:", "ro:", 1), ":", 3)
+			if len(fields) == 3 {
+				route.BGP.ExtCommunities = append(route.BGP.ExtCommunities, fields)
+			}
+		}
+		response.Routes = append(response.Routes, route)
+	}
+	return response
+}
+
+// birdwatcherCommunity splits a standard or large community into its parts.
+func birdwatcherCommunity(community string, parts int) ([]uint32, bool) {
+	fields := strings.Split(community, ":")
+	if len(fields) != parts {
+		return nil, false
+	}
+	values := make([]uint32, 0, parts)
+	for _, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, uint32(value))
+	}
+	return values, true
+}
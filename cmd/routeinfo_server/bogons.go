@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type BogonResult struct {
+	Router string `json:"router"`
+	routeinfo.BogonReport
+}
+
+type BogonResponse struct {
+	Errors  []string      `json:"errors"`
+	Results []BogonResult `json:"results"`
+}
+
+func bogons(writer http.ResponseWriter, request *http.Request) {
+	var response BogonResponse
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+
+	for routerName, router := range routers {
+		response.Results = append(response.Results, BogonResult{
+			Router:      routerName,
+			BogonReport: router.Bogons(),
+		})
+	}
+
+	writeJSON(writer, response)
+}
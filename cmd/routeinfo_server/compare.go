@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type CompareResponse struct {
+	Errors []string                    `json:"errors"`
+	Result *routeinfo.PrefixComparison `json:"result"`
+}
+
+func compare(writer http.ResponseWriter, request *http.Request) {
+	var response CompareResponse
+
+	qPrefix := request.URL.Query().Get("prefix")
+	var routers []string
+	if qRouters := request.URL.Query().Get("routers"); qRouters != "" {
+		routers = strings.Split(qRouters, ",")
+	}
+
+	comparison, err := rs.Compare(qPrefix, routers...)
+	if err != nil {
+		response.Errors = append(response.Errors, err.Error())
+	} else {
+		response.Result = &comparison
+	}
+
+	writeJSON(writer, response)
+}
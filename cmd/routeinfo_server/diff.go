@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// periodicSnapshot is the name of the snapshots taken every -snapshotInterval.
+const periodicSnapshot = "last"
+
+type SnapshotsResponse struct {
+	Errors  []string                 `json:"errors"`
+	Results []routeinfo.SnapshotInfo `json:"results"`
+}
+
+// diff streams the prefixes whose best paths differ between two routers or
+// snapshots as JSON lines, one prefix per line.
+func diff(writer http.ResponseWriter, request *http.Request) {
+	qA := request.URL.Query().Get("a")
+	qB := request.URL.Query().Get("b")
+
+	writer.Header().Set("Content-Type", "application/x-ndjson")
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	controller := http.NewResponseController(writer)
+	encoder := json.NewEncoder(writer)
+	err := rs.DiffTables(qA, qB, func(diff routeinfo.TableDiff) bool {
+		if err := encoder.Encode(diff); err != nil {
+			log.Error().Err(err).Msg("Http Request error")
+			return false
+		}
+		controller.Flush()
+		return request.Context().Err() == nil
+	})
+	if err != nil {
+		// references are resolved before the first line is written
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// listSnapshots lists the snapshots which can be compared by /diff.
+func listSnapshots(writer http.ResponseWriter, request *http.Request) {
+	writeJSON(writer, SnapshotsResponse{Results: rs.Snapshots()})
+}
+
+// takeSnapshots takes a snapshot of the selected routers under the name given
+// by the "name" query parameter.
+func takeSnapshots(writer http.ResponseWriter, request *http.Request) {
+	var response SnapshotsResponse
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+	name := request.URL.Query().Get("name")
+	for routerName := range routers {
+		if err := rs.TakeSnapshot(routerName, name); err != nil {
+			response.Errors = append(response.Errors, err.Error())
+			break
+		}
+	}
+	response.Results = rs.Snapshots()
+	writeJSON(writer, response)
+}
+
+// takePeriodicSnapshots replaces the snapshot "last" of each router every
+// interval, so /diff can show what changed since.
+func takePeriodicSnapshots(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for name := range rs.Routers {
+			if err := rs.TakeSnapshot(name, periodicSnapshot); err != nil {
+				log.Error().Err(err).Msgf("Failed to take snapshot of %s", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// events streams the events of all routers as JSON lines until the client
+// disconnects.
+func events(writer http.ResponseWriter, request *http.Request) {
+	stream, unsubscribe := rs.Subscribe(100)
+	defer unsubscribe()
+
+	writer.Header().Set("Content-Type", "application/x-ndjson")
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	writer.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(writer)
+	controller.Flush()
+	encoder := json.NewEncoder(writer)
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case event := <-stream:
+			if err := encoder.Encode(event); err != nil {
+				log.Error().Err(err).Msg("Http Request error")
+				return
+			}
+			controller.Flush()
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv",
+	"jsonl":  "application/x-ndjson",
+	"ip2asn": "text/tab-separated-values",
+	"pmacct": "text/plain",
+}
+
+// export writes the best path mapping of the whole table of a router for use
+// in other tools, e.g. flow collectors.
+func export(writer http.ResponseWriter, request *http.Request) {
+	qRouter := request.URL.Query().Get("router")
+	router, ok := rs.Routers[qRouter]
+	if !ok {
+		http.Error(writer, "Router not found.", http.StatusBadRequest)
+		return
+	}
+	format := request.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if !slices.Contains(routeinfo.ExportFormats, format) {
+		http.Error(writer, "Unknown format.", http.StatusBadRequest)
+		return
+	}
+
+	writer.Header().Set("Content-Type", exportContentTypes[format])
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", qRouter+"."+format))
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if err := router.Export(writer, format); err != nil {
+		log.Error().Err(err).Msg("Http Request error")
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io"
+	"maps"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+var graphContentTypes = map[string]string{
+	"dot":     "text/vnd.graphviz",
+	"svg":     "image/svg+xml",
+	"mermaid": "text/plain",
+}
+
+// graphHandler renders the AS level tree of the paths to a prefix on the
+// selected routers, drawn with the options of the looking glass.
+func graphHandler(options routeinfo.GraphOptions) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		graph(writer, request, options)
+	}
+}
+
+func graph(writer http.ResponseWriter, request *http.Request, options routeinfo.GraphOptions) {
+	routers, errors := selectedRouters(request)
+	if len(errors) > 0 {
+		http.Error(writer, errors[0], http.StatusBadRequest)
+		return
+	}
+	qPrefix := request.URL.Query().Get("prefix")
+	if qPrefix == "" {
+		http.Error(writer, "Missing prefix.", http.StatusBadRequest)
+		return
+	}
+	format := request.URL.Query().Get("format")
+	if format == "" {
+		format = "svg"
+	}
+	if !slices.Contains(routeinfo.GraphFormats, format) {
+		http.Error(writer, "Unknown format.", http.StatusBadRequest)
+		return
+	}
+
+	// the routers may know different prefixes for an address, only the paths
+	// of the most specific one are drawn
+	var paths []routeinfo.RouteInfo
+	bits := -1
+	for _, name := range slices.Sorted(maps.Keys(routers)) {
+		found := routers[name].Lookup(qPrefix)
+		if len(found) == 0 {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(found[0].Prefix)
+		if err != nil {
+			continue
+		}
+		if prefix.Bits() > bits {
+			paths, bits = nil, prefix.Bits()
+		}
+		if prefix.Bits() == bits {
+			paths = append(paths, found...)
+		}
+	}
+	if len(paths) == 0 {
+		http.Error(writer, "No paths found.", http.StatusNotFound)
+		return
+	}
+	rendered, err := routeinfo.NewASGraph(options, paths).Render(format)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writer.Header().Set("Content-Type", graphContentTypes[format])
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if _, err := io.WriteString(writer, rendered); err != nil {
+		log.Error().Err(err).Msg("Http Request error")
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+	"net/netip"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type IRRResponse struct {
+	Errors       []string                `json:"errors"`
+	RouteObjects []routeinfo.RouteObject `json:"routeobjects"`
+	Results      []PrefixResult          `json:"results"`
+}
+
+// irr returns the route objects covering a prefix and the paths to it, each
+// with the result of its IRR validation.
+func irr(writer http.ResponseWriter, request *http.Request) {
+	var response IRRResponse
+
+	if rs.IRR == nil {
+		response.Errors = append(response.Errors, "IRR validation is not configured.")
+		writeJSON(writer, response)
+		return
+	}
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+
+	qPrefix := request.URL.Query().Get("prefix")
+	for routerName, router := range routers {
+		paths := router.Lookup(qPrefix)
+		if len(paths) > 0 {
+			response.Results = append(response.Results, PrefixResult{
+				Router: routerName,
+				Prefix: paths[0].Prefix,
+				Paths:  paths,
+			})
+		}
+	}
+
+	// the looked up prefix if an address was given
+	prefix, err := netip.ParsePrefix(qPrefix)
+	if len(response.Results) > 0 {
+		prefix, err = netip.ParsePrefix(response.Results[0].Prefix)
+	} else if address, addrErr := netip.ParseAddr(qPrefix); addrErr == nil {
+		prefix, err = address.Prefix(address.BitLen())
+	}
+	if err != nil {
+		response.Errors = append(response.Errors, "Invalid prefix.")
+	} else {
+		response.RouteObjects = rs.IRR.Covering(prefix)
+	}
+
+	writeJSON(writer, response)
+}
@@ -3,26 +3,33 @@ package main
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/BelWue/bgp_routeinfo/grpcapi"
 	applog "github.com/BelWue/bgp_routeinfo/log"
+	"github.com/BelWue/bgp_routeinfo/lookingglass"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"github.com/BelWue/bgp_routeinfo/routeinfo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"sigs.k8s.io/yaml"
 )
 
 type PrefixResult struct {
-	Router string                `json:"router"`
-	Prefix string                `json:"prefix"`
-	Paths  []routeinfo.RouteInfo `json:"paths"`
+	Router      string                   `json:"router"`
+	Prefix      string                   `json:"prefix"`
+	Paths       []routeinfo.RouteInfo    `json:"paths"`
+	Explanation []routeinfo.PathDecision `json:"explanation,omitempty"`
 }
 
 type RouterStatus struct {
@@ -47,8 +54,13 @@ func main() {
 	configfile := flag.String("c", "config.yml", "location of the config file in yml format")
 	jsonLogging := flag.Bool("j", false, "Json log")
 	endpoint := flag.String("e", ":3000", "Endpoint the service should listen/serve on")
+	grpcEndpoint := flag.String("grpc", "", "Endpoint the gRPC service should listen on, disabled if empty")
+	whoisEndpoint := flag.String("whois", "", "Endpoint the whois service should listen on, i.e. :43, disabled if empty")
 	logLevelString := flag.String("l", "info", "Loglevel: one of 'debug', 'info', 'warning' or 'error'")
 	enableBgpLog := flag.Bool("enableBgpLog", false, "Enable log for gobgp")
+	mmdbDirectory := flag.String("mmdb", "", "Directory to periodically write a MaxMind DB of the best paths of each router to, disabled if empty")
+	mmdbInterval := flag.Duration("mmdbInterval", time.Hour, "Interval between writes of the MaxMind DBs")
+	snapshotInterval := flag.Duration("snapshotInterval", 0, "Interval between snapshots named \"last\" of each router for /diff, disabled if 0")
 	flag.Parse()
 
 	if !*jsonLogging {
@@ -76,6 +88,21 @@ func main() {
 	}
 	rs.Init() // try to establish all sessions
 
+	// the settings of the looking glass are part of the same file
+	site := struct {
+		LookingGlass lookingglass.Config `yaml:"lookingglass"`
+	}{LookingGlass: lookingglass.DefaultConfig()}
+	if err := yaml.Unmarshal(config, &site); err != nil {
+		log.Fatal().Err(err).Msg("Error parsing configuration YAML")
+	}
+	if site.LookingGlass.LocalASName == "" {
+		site.LookingGlass.LocalASName = fmt.Sprintf("AS%d", rs.Asn)
+	}
+	configJS, err := site.LookingGlass.ConfigJS(rs.Communities.Entries())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error generating looking glass config")
+	}
+
 	// clean shutdown on ^C
 	sigc := make(chan os.Signal, 1)
 	signal.Notify(sigc,
@@ -88,8 +115,63 @@ func main() {
 		os.Exit(0)
 	}()
 
+	if *grpcEndpoint != "" {
+		listener, err := net.Listen("tcp", *grpcEndpoint)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to listen on %s", *grpcEndpoint)
+		}
+		go func() {
+			if err := grpcapi.NewServer(&rs).Serve(listener); err != nil {
+				log.Fatal().Err(err).Msg("gRPC server failed")
+			}
+		}()
+	}
+	if *whoisEndpoint != "" {
+		listener, err := net.Listen("tcp", *whoisEndpoint)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to listen on %s", *whoisEndpoint)
+		}
+		go serveWhois(listener)
+	}
+	if *mmdbDirectory != "" {
+		go writeMMDBs(*mmdbDirectory, *mmdbInterval)
+	}
+	if *snapshotInterval > 0 {
+		go takePeriodicSnapshots(*snapshotInterval)
+	}
+
 	http.HandleFunc("/prefix", prefix)
 	http.HandleFunc("/status", status)
+	http.HandleFunc("/neighbors", neighbors)
+	http.HandleFunc("/origin", origin)
+	http.HandleFunc("/search/aspath", searchASPath)
+	http.HandleFunc("/search/community", searchCommunity)
+	http.HandleFunc("/search/nexthop", searchNextHop)
+	http.HandleFunc("/search/peer", searchPeer)
+	http.HandleFunc("/query", query)
+	http.HandleFunc("/stats", stats)
+	http.HandleFunc("/compare", compare)
+	http.HandleFunc("/diff", diff)
+	http.HandleFunc("GET /snapshots", listSnapshots)
+	http.HandleFunc("POST /snapshots", takeSnapshots)
+	http.HandleFunc("/events", events)
+	http.HandleFunc("/bogons", bogons)
+	http.HandleFunc("/export", export)
+	http.HandleFunc("/irr", irr)
+	http.HandleFunc("/graph", graphHandler(routeinfo.GraphOptions{
+		LocalName:       site.LookingGlass.LocalASName,
+		DrawLocalASLoop: site.LookingGlass.DrawLocalASLoop,
+		DrawPrepends:    site.LookingGlass.DrawPrepends,
+	}))
+	http.HandleFunc("GET /birdwatcher/{router}/status", birdwatcherStatus)
+	http.HandleFunc("GET /birdwatcher/{router}/protocols/bgp", birdwatcherProtocols)
+	http.HandleFunc("GET /birdwatcher/{router}/routes/protocol/{id}", birdwatcherRoutesProtocol)
+	http.HandleFunc("GET /birdwatcher/{router}/routes/filtered/{id}", birdwatcherNoRoutes)
+	http.HandleFunc("GET /birdwatcher/{router}/routes/noexport/{id}", birdwatcherNoRoutes)
+	http.HandleFunc("GET /birdwatcher/{router}/routes/prefix", birdwatcherRoutesPrefix)
+	http.Handle("/", lookingglass.Handler(configJS))
+	prometheus.MustRegister(routeinfo.Collectors()...)
+	http.Handle("/metrics", promhttp.Handler())
 	err = http.ListenAndServe(*endpoint, nil)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("Failed to listen on %s", *endpoint)
@@ -105,10 +187,37 @@ func status(writer http.ResponseWriter, request *http.Request) {
 		rStatus.Ready = router.Established()
 		response.Results = append(response.Results, rStatus)
 	}
+	writeJSON(writer, response)
+}
+
+// selectedRouters returns the routers given by the "router" query parameter,
+// or all routers if there is none.
+func selectedRouters(request *http.Request) (map[string]*routeinfo.Router, []string) {
+	var errors []string
+	qRouters := request.URL.Query()["router"]
+	routers := make(map[string]*routeinfo.Router)
+	if (len(qRouters) == 1 && len(qRouters[0]) > 0) || len(qRouters) > 1 {
+		for _, qRouter := range qRouters {
+			if router, ok := rs.Routers[qRouter]; ok {
+				routers[qRouter] = router
+			} else {
+				errors = append(errors, "Router not found.")
+			}
+		}
+	} else {
+		// no filter for router name, so use all routers
+		routers = rs.Routers
+	}
+	return routers, errors
+}
+
+func writeJSON(writer http.ResponseWriter, response any) {
 	body, err := json.Marshal(response)
 	if err != nil {
 		// can't really add error strings to the body here anymore...
 		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		log.Error().Err(err).Msg("Http Request error")
+		return
 	}
 
 	writer.Header().Set("Content-Type", "application/json")
@@ -121,22 +230,11 @@ func status(writer http.ResponseWriter, request *http.Request) {
 func prefix(writer http.ResponseWriter, request *http.Request) {
 	var response PrefixResponse
 
-	qRouters := request.URL.Query()["router"]
-	routers := make(map[string]*routeinfo.Router)
-	if (len(qRouters) == 1 && len(qRouters[0]) > 0) || len(qRouters) > 1 {
-		for _, qRouter := range qRouters {
-			if router, ok := rs.Routers[qRouter]; ok {
-				routers[qRouter] = router
-			} else {
-				response.Errors = append(response.Errors, "Router not found.")
-			}
-		}
-	} else {
-		// no filter for router name, so use all routers
-		routers = rs.Routers
-	}
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
 
 	qPrefix := request.URL.Query().Get("prefix")
+	qExplain := request.URL.Query().Get("explain") == "true"
 	// TODO: properly validate input
 	//var valid = true
 	//if !valid {
@@ -155,20 +253,12 @@ func prefix(writer http.ResponseWriter, request *http.Request) {
 					break
 				}
 			}
+			if qExplain {
+				pr.Explanation = routeinfo.ExplainBestPath(pr.Paths)
+			}
 			response.Results = append(response.Results, pr)
 		}
 	}
 
-	body, err := json.Marshal(response)
-	if err != nil {
-		// can't really add error strings to the body here anymore...
-		http.Error(writer, err.Error(), http.StatusInternalServerError)
-		log.Error().Err(err).Msg("Http Request error")
-	}
-
-	writer.Header().Set("Content-Type", "application/json")
-	writer.Header().Set("Access-Control-Allow-Origin", "*")
-	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
-	writer.Write(body)
+	writeJSON(writer, response)
 }
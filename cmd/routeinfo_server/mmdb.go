@@ -0,0 +1,31 @@
+package main
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// writeMMDBs writes a MaxMind DB with the best paths of each router to
+// <router>.mmdb in the directory once all routers received their paths, and
+// again every interval.
+func writeMMDBs(directory string, interval time.Duration) {
+	for _, router := range rs.Routers {
+		router.WaitForEOR()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for name, router := range rs.Routers {
+			path := filepath.Join(directory, name+".mmdb")
+			start := time.Now()
+			if err := router.WriteMMDB(path); err != nil {
+				log.Error().Err(err).Msgf("Failed to write %s", path)
+				continue
+			}
+			log.Debug().Msgf("Wrote %s in %s", path, time.Since(start))
+		}
+		<-ticker.C
+	}
+}
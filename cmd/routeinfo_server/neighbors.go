@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type NeighborsResult struct {
+	Router    string                     `json:"router"`
+	Neighbors []routeinfo.NeighborStatus `json:"neighbors"`
+}
+
+type NeighborsResponse struct {
+	Errors  []string          `json:"errors"`
+	Results []NeighborsResult `json:"results"`
+}
+
+func neighbors(writer http.ResponseWriter, request *http.Request) {
+	var response NeighborsResponse
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+
+	for routerName, router := range routers {
+		response.Results = append(response.Results, NeighborsResult{
+			Router:    routerName,
+			Neighbors: router.NeighborStatus(),
+		})
+	}
+
+	writeJSON(writer, response)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type OriginResult struct {
+	Router string `json:"router"`
+	routeinfo.OriginPrefixes
+}
+
+type OriginResponse struct {
+	Errors  []string       `json:"errors"`
+	Results []OriginResult `json:"results"`
+}
+
+func origin(writer http.ResponseWriter, request *http.Request) {
+	var response OriginResponse
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+
+	qAsn := strings.TrimPrefix(strings.ToUpper(request.URL.Query().Get("asn")), "AS")
+	asn, err := strconv.ParseUint(qAsn, 10, 32)
+	if err != nil {
+		response.Errors = append(response.Errors, "Invalid ASN.")
+		writeJSON(writer, response)
+		return
+	}
+
+	for routerName, router := range routers {
+		response.Results = append(response.Results, OriginResult{
+			Router:         routerName,
+			OriginPrefixes: router.PrefixesByOriginAS(uint32(asn)),
+		})
+	}
+
+	writeJSON(writer, response)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type QueryResult struct {
+	Router string `json:"router"`
+	routeinfo.RouteInfo
+}
+
+// query streams all paths matching a filter expression as JSON lines, one
+// path per line. Errors are reported before the first line is written.
+func query(writer http.ResponseWriter, request *http.Request) {
+	routers, errors := selectedRouters(request)
+	compiled, err := routeinfo.CompileQuery(request.URL.Query().Get("q"))
+	if err != nil {
+		errors = append(errors, err.Error())
+	}
+	if len(errors) > 0 {
+		http.Error(writer, strings.Join(errors, "\n"), http.StatusBadRequest)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/x-ndjson")
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	writer.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	controller := http.NewResponseController(writer)
+	encoder := json.NewEncoder(writer)
+	for routerName, router := range routers {
+		router.WalkQuery(compiled, func(path routeinfo.RouteInfo) bool {
+			if err := encoder.Encode(QueryResult{Router: routerName, RouteInfo: path}); err != nil {
+				log.Error().Err(err).Msg("Http Request error")
+				return false
+			}
+			return request.Context().Err() == nil
+		})
+		if request.Context().Err() != nil {
+			return
+		}
+		controller.Flush()
+	}
+}
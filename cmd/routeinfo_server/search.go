@@ -0,0 +1,105 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 10000
+)
+
+type SearchResult struct {
+	Router string                `json:"router"`
+	Total  int                   `json:"total"`
+	Offset int                   `json:"offset"`
+	Paths  []routeinfo.RouteInfo `json:"paths"`
+}
+
+type SearchResponse struct {
+	Errors  []string       `json:"errors"`
+	Results []SearchResult `json:"results"`
+}
+
+// pagination reads the "offset" and "limit" query parameters.
+func pagination(request *http.Request) (int, int, []string) {
+	var errors []string
+	offset, limit := 0, defaultPageLimit
+	if qOffset := request.URL.Query().Get("offset"); qOffset != "" {
+		value, err := strconv.Atoi(qOffset)
+		if err != nil || value < 0 {
+			errors = append(errors, "Invalid offset.")
+		} else {
+			offset = value
+		}
+	}
+	if qLimit := request.URL.Query().Get("limit"); qLimit != "" {
+		value, err := strconv.Atoi(qLimit)
+		if err != nil || value <= 0 || value > maxPageLimit {
+			errors = append(errors, "Invalid limit.")
+		} else {
+			limit = value
+		}
+	}
+	return offset, limit, errors
+}
+
+// search runs the same search on all selected routers and writes a paginated
+// response. Only the paths of the requested page are kept per router.
+func search(writer http.ResponseWriter, request *http.Request, fn func(router *routeinfo.Router, add func(routeinfo.RouteInfo)) error) {
+	var response SearchResponse
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+	offset, limit, errors := pagination(request)
+	response.Errors = append(response.Errors, errors...)
+
+	if len(errors) == 0 {
+		for routerName, router := range routers {
+			window := routeinfo.NewPathWindow(offset, limit)
+			if err := fn(router, window.Add); err != nil {
+				response.Errors = append(response.Errors, err.Error())
+				break
+			}
+			response.Results = append(response.Results, SearchResult{
+				Router: routerName,
+				Total:  window.Total,
+				Offset: offset,
+				Paths:  window.Paths(),
+			})
+		}
+	}
+
+	writeJSON(writer, response)
+}
+
+func searchASPath(writer http.ResponseWriter, request *http.Request) {
+	regex := request.URL.Query().Get("regex")
+	search(writer, request, func(router *routeinfo.Router, add func(routeinfo.RouteInfo)) error {
+		return router.SearchASPath(regex, add)
+	})
+}
+
+func searchCommunity(writer http.ResponseWriter, request *http.Request) {
+	community := request.URL.Query().Get("c")
+	search(writer, request, func(router *routeinfo.Router, add func(routeinfo.RouteInfo)) error {
+		return router.PrefixesWithCommunity(community, add)
+	})
+}
+
+func searchNextHop(writer http.ResponseWriter, request *http.Request) {
+	address := request.URL.Query().Get("ip")
+	search(writer, request, func(router *routeinfo.Router, add func(routeinfo.RouteInfo)) error {
+		return router.SearchNextHop(address, add)
+	})
+}
+
+func searchPeer(writer http.ResponseWriter, request *http.Request) {
+	address := request.URL.Query().Get("ip")
+	search(writer, request, func(router *routeinfo.Router, add func(routeinfo.RouteInfo)) error {
+		return router.SearchPeer(address, add)
+	})
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+type StatsResult struct {
+	Router string `json:"router"`
+	routeinfo.TableStats
+}
+
+type StatsResponse struct {
+	Errors  []string      `json:"errors"`
+	Results []StatsResult `json:"results"`
+}
+
+func stats(writer http.ResponseWriter, request *http.Request) {
+	var response StatsResponse
+
+	routers, errors := selectedRouters(request)
+	response.Errors = append(response.Errors, errors...)
+
+	for routerName, router := range routers {
+		response.Results = append(response.Results, StatsResult{
+			Router:     routerName,
+			TableStats: router.Stats(),
+		})
+	}
+
+	writeJSON(writer, response)
+}
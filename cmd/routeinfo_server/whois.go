@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// The whois server answers queries like "192.0.2.1" or "-r rt-1 -b
+// 192.0.2.0/24" with one line per path. As with the bulk mode of the Team
+// Cymru whois, multiple queries can be sent between "begin" and "end" lines.
+
+// whoisTimeout is the time a client has to send the next line.
+const whoisTimeout = 30 * time.Second
+
+// whoisMaxConnections is the number of clients served at the same time, more
+// are turned away.
+const whoisMaxConnections = 32
+
+const whoisHelp = `% Usage: [-r router] [-b] <prefix or address>
+%   -r router  only show paths of this router, may be given multiple times
+%   -b         only show best paths
+% Send multiple queries between "begin" and "end" lines.
+`
+
+func serveWhois(listener net.Listener) {
+	connections := make(chan struct{}, whoisMaxConnections)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Error().Err(err).Msg("whois server failed")
+			return
+		}
+		select {
+		case connections <- struct{}{}:
+			go func() {
+				defer func() { <-connections }()
+				handleWhois(conn)
+			}()
+		default:
+			conn.SetWriteDeadline(time.Now().Add(time.Second))
+			io.WriteString(conn, "% Too many connections, try again later\n")
+			conn.Close()
+		}
+	}
+}
+
+func handleWhois(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	// the paths of each query are aligned and flushed once it is answered
+	writer := tabwriter.NewWriter(conn, 0, 0, 1, ' ', 0)
+
+	conn.SetReadDeadline(time.Now().Add(whoisTimeout))
+	if !scanner.Scan() {
+		return
+	}
+	line := strings.TrimSpace(scanner.Text())
+	if line != "begin" {
+		whoisQuery(writer, line, true)
+		writer.Flush()
+		return
+	}
+	header := true
+	for {
+		conn.SetReadDeadline(time.Now().Add(whoisTimeout))
+		if !scanner.Scan() {
+			return
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "end" {
+			return
+		}
+		if line == "" {
+			continue
+		}
+		if whoisQuery(writer, line, header) {
+			header = false
+		}
+		writer.Flush()
+	}
+}
+
+// whoisQuery writes the paths matching a query, preceded by a header if
+// requested. It returns whether paths were written.
+func whoisQuery(writer io.Writer, query string, header bool) bool {
+	var (
+		names    []string
+		bestOnly bool
+		prefix   string
+	)
+	fields := strings.Fields(query)
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "-h", "help":
+			io.WriteString(writer, whoisHelp)
+			return false
+		case "-b":
+			bestOnly = true
+		case "-r":
+			if i+1 == len(fields) {
+				fmt.Fprintf(writer, "%% Missing router: %s\n", query)
+				return false
+			}
+			i++
+			names = append(names, fields[i])
+		default:
+			if prefix != "" {
+				fmt.Fprintf(writer, "%% Invalid query: %s\n", query)
+				return false
+			}
+			prefix = fields[i]
+		}
+	}
+	if prefix == "" {
+		io.WriteString(writer, whoisHelp)
+		return false
+	}
+	if len(names) == 0 {
+		for name := range rs.Routers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+	}
+
+	found := false
+	for _, name := range names {
+		router, ok := rs.Routers[name]
+		if !ok {
+			fmt.Fprintf(writer, "%% Router not found: %s\n", name)
+			continue
+		}
+		for _, path := range router.Lookup(prefix) {
+			if bestOnly && !path.Best {
+				continue
+			}
+			if header && !found {
+				fmt.Fprintln(writer, "Router\t| Prefix\t| Best\t| AS Path\t| Next Hop\t| LocPrf\t| MED\t| RPKI\t| Communities")
+			}
+			found = true
+			whoisPath(writer, name, path)
+		}
+	}
+	if !found {
+		fmt.Fprintf(writer, "%% No paths found: %s\n", prefix)
+	}
+	return found
+}
+
+func whoisPath(writer io.Writer, name string, path routeinfo.RouteInfo) {
+	aspath := make([]string, len(path.AsPath))
+	for i, asn := range path.AsPath {
+		aspath[i] = strconv.FormatUint(uint64(asn), 10)
+	}
+	best := ""
+	if path.Best {
+		best = "*"
+	}
+	communities := slices.Concat(path.Communities, path.LargeCommunities)
+	fmt.Fprintf(writer, "%s\t| %s\t| %s\t| %s\t| %s\t| %d\t| %d\t| %s\t| %s\n",
+		name, path.Prefix, best, strings.Join(aspath, " "), path.NextHop, path.LocalPref, path.Med,
+		path.Validation, strings.Join(communities, " "))
+}
@@ -0,0 +1,31 @@
+package grpcapi
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client calls the RouteInfo service, the messages of the responses can be
+// converted to the types of the routeinfo package using their ToRouteInfo
+// methods.
+type Client struct {
+	RouteInfoClient
+	conn *grpc.ClientConn
+}
+
+// NewClient connects to the RouteInfo service at target, i.e.
+// "localhost:3001". Without options, the connection is not encrypted.
+func NewClient(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{RouteInfoClient: NewRouteInfoClient(conn), conn: conn}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,249 @@
+// Package grpcapi is a gRPC API for a RouteInfoServer mirroring the HTTP
+// endpoints of routeinfo_server, and a client for it.
+//
+// The service is defined in routeinfo.proto, from which clients in other
+// languages can be generated, i.e. in Python:
+//
+//	python -m grpc_tools.protoc -I. --python_out=. --grpc_python_out=. routeinfo.proto
+//
+//	stub = routeinfo_pb2_grpc.RouteInfoStub(grpc.insecure_channel("localhost:3001"))
+//	stub.Lookup(routeinfo_pb2.LookupRequest(prefix="192.0.2.0/24"))
+//
+// The messages are converted from and to the types of the routeinfo package
+// by the New* functions and ToRouteInfo methods.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative routeinfo.proto
+
+import (
+	"net/netip"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/BelWue/bgp_routeinfo/asnames"
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// newTimestamp returns nil for the zero time.
+func newTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// asTime returns the zero time for nil.
+func asTime(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+// NewPath converts a path to its message.
+func NewPath(path routeinfo.RouteInfo) *Path {
+	p := &Path{
+		Prefix:              path.Prefix,
+		Peer:                path.Peer,
+		NextHop:             path.NextHop,
+		AsPath:              path.AsPath,
+		OriginAs:            path.OriginAs,
+		Origin:              Origin(path.Origin),
+		LocalPref:           path.LocalPref,
+		Med:                 path.Med,
+		Best:                path.Best,
+		Communities:         path.Communities,
+		LargeCommunities:    path.LargeCommunities,
+		ExtendedCommunities: path.ExtendedCommunities,
+		OriginatorId:        path.OriginatorId,
+		PathId:              path.PathId,
+		ClusterList:         path.ClusterList,
+		RouterId:            path.RouterId,
+		Timestamp:           newTimestamp(path.Timestamp),
+		Validation:          ValidationState(path.Validation),
+	}
+	for _, community := range path.AnnotatedCommunities {
+		p.AnnotatedCommunities = append(p.AnnotatedCommunities, &AnnotatedCommunity{
+			Community:   community.Community,
+			Description: community.Description,
+			Class:       community.Class,
+		})
+	}
+	for _, vrp := range path.VRPs {
+		p.Vrps = append(p.Vrps, &VRP{Prefix: vrp.Prefix.String(), MaxLength: uint32(vrp.MaxLength), Asn: vrp.Asn})
+	}
+	if path.ASPA != nil {
+		p.Aspa = &ASPAResult{State: ASPAState(path.ASPA.State), Downstream: path.ASPA.Downstream}
+		if path.ASPA.Hop != nil {
+			p.Aspa.Hop = &ASPAHop{Customer: path.ASPA.Hop.Customer, Provider: path.ASPA.Hop.Provider}
+		}
+	}
+	for _, name := range path.AsPathNames {
+		p.AsPathNames = append(p.AsPathNames, &ASName{
+			Asn:          name.Asn,
+			Name:         name.Name,
+			Organization: name.Organization,
+			Country:      name.Country,
+			Registry:     name.Registry,
+		})
+	}
+	for _, reason := range path.Bogon {
+		p.Bogon = append(p.Bogon, &BogonReason{Kind: string(reason.Kind), Match: reason.Match, Description: reason.Description})
+	}
+	if path.IRR != nil {
+		p.Irr = &IRRResult{
+			RouteObject: path.IRR.RouteObject,
+			Origins:     path.IRR.Origins,
+			AsSet:       path.IRR.ASSet,
+			InAsSet:     path.IRR.InASSet,
+		}
+	}
+	return p
+}
+
+// ToRouteInfo converts the message back to a path.
+func (p *Path) ToRouteInfo() routeinfo.RouteInfo {
+	path := routeinfo.RouteInfo{
+		Prefix:              p.GetPrefix(),
+		Peer:                p.GetPeer(),
+		NextHop:             p.GetNextHop(),
+		AsPath:              p.GetAsPath(),
+		OriginAs:            p.GetOriginAs(),
+		Origin:              routeinfo.OriginValue(p.GetOrigin()),
+		LocalPref:           p.GetLocalPref(),
+		Med:                 p.GetMed(),
+		Best:                p.GetBest(),
+		Communities:         p.GetCommunities(),
+		LargeCommunities:    p.GetLargeCommunities(),
+		ExtendedCommunities: p.GetExtendedCommunities(),
+		OriginatorId:        p.GetOriginatorId(),
+		PathId:              p.GetPathId(),
+		ClusterList:         p.GetClusterList(),
+		RouterId:            p.GetRouterId(),
+		Timestamp:           asTime(p.GetTimestamp()),
+		Validation:          bgp.ValidationState(p.GetValidation()),
+	}
+	for _, community := range p.GetAnnotatedCommunities() {
+		path.AnnotatedCommunities = append(path.AnnotatedCommunities, routeinfo.AnnotatedCommunity{
+			Community:   community.GetCommunity(),
+			Description: community.GetDescription(),
+			Class:       community.GetClass(),
+		})
+	}
+	for _, vrp := range p.GetVrps() {
+		prefix, _ := netip.ParsePrefix(vrp.GetPrefix())
+		path.VRPs = append(path.VRPs, routeinfo.VRP{Prefix: prefix, MaxLength: uint8(vrp.GetMaxLength()), Asn: vrp.GetAsn()})
+	}
+	if aspa := p.GetAspa(); aspa != nil {
+		path.ASPA = &routeinfo.ASPAResult{State: routeinfo.ASPAState(aspa.GetState()), Downstream: aspa.GetDownstream()}
+		if hop := aspa.GetHop(); hop != nil {
+			path.ASPA.Hop = &routeinfo.ASPAHop{Customer: hop.GetCustomer(), Provider: hop.GetProvider()}
+		}
+	}
+	for _, name := range p.GetAsPathNames() {
+		path.AsPathNames = append(path.AsPathNames, asnames.Name{
+			Asn:          name.GetAsn(),
+			Name:         name.GetName(),
+			Organization: name.GetOrganization(),
+			Country:      name.GetCountry(),
+			Registry:     name.GetRegistry(),
+		})
+	}
+	for _, reason := range p.GetBogon() {
+		path.Bogon = append(path.Bogon, routeinfo.BogonReason{
+			Kind:        routeinfo.BogonKind(reason.GetKind()),
+			Match:       reason.GetMatch(),
+			Description: reason.GetDescription(),
+		})
+	}
+	if irr := p.GetIrr(); irr != nil {
+		path.IRR = &routeinfo.IRRResult{
+			RouteObject: irr.GetRouteObject(),
+			Origins:     irr.GetOrigins(),
+			ASSet:       irr.GetAsSet(),
+			InASSet:     irr.GetInAsSet(),
+		}
+	}
+	return path
+}
+
+// NewPaths converts paths to their messages.
+func NewPaths(paths []routeinfo.RouteInfo) []*Path {
+	messages := make([]*Path, len(paths))
+	for i, path := range paths {
+		messages[i] = NewPath(path)
+	}
+	return messages
+}
+
+// NewPathDecision converts a decision of the best path selection to its
+// message.
+func NewPathDecision(decision routeinfo.PathDecision) *PathDecision {
+	return &PathDecision{Path: NewPath(decision.Path), LostAt: string(decision.LostAt), Reason: decision.Reason}
+}
+
+// ToRouteInfo converts the message back to a decision.
+func (d *PathDecision) ToRouteInfo() routeinfo.PathDecision {
+	return routeinfo.PathDecision{
+		Path:   d.GetPath().ToRouteInfo(),
+		LostAt: routeinfo.DecisionStep(d.GetLostAt()),
+		Reason: d.GetReason(),
+	}
+}
+
+// NewNeighborStatus converts the state of a session to its message.
+func NewNeighborStatus(neighbor routeinfo.NeighborStatus) *NeighborStatus {
+	return &NeighborStatus{
+		Address:  neighbor.Address,
+		Asn:      neighbor.Asn,
+		State:    neighbor.State,
+		Since:    newTimestamp(neighbor.Since),
+		Received: neighbor.Received,
+		Accepted: neighbor.Accepted,
+	}
+}
+
+// ToRouteInfo converts the message back to the state of a session.
+func (n *NeighborStatus) ToRouteInfo() routeinfo.NeighborStatus {
+	return routeinfo.NeighborStatus{
+		Address:  n.GetAddress(),
+		Asn:      n.GetAsn(),
+		State:    n.GetState(),
+		Since:    asTime(n.GetSince()),
+		Received: n.GetReceived(),
+		Accepted: n.GetAccepted(),
+	}
+}
+
+// NewEvent converts an event to its message.
+func NewEvent(event routeinfo.Event) *Event {
+	e := &Event{
+		Type:    string(event.Type),
+		Time:    newTimestamp(event.Time),
+		Router:  event.Router,
+		Prefix:  event.Prefix,
+		Message: event.Message,
+	}
+	if event.Path != nil {
+		e.Path = NewPath(*event.Path)
+	}
+	return e
+}
+
+// ToRouteInfo converts the message back to an event.
+func (e *Event) ToRouteInfo() routeinfo.Event {
+	event := routeinfo.Event{
+		Type:    routeinfo.EventType(e.GetType()),
+		Time:    asTime(e.GetTime()),
+		Router:  e.GetRouter(),
+		Prefix:  e.GetPrefix(),
+		Message: e.GetMessage(),
+	}
+	if e.GetPath() != nil {
+		path := e.GetPath().ToRouteInfo()
+		event.Path = &path
+	}
+	return event
+}
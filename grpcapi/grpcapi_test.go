@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/BelWue/bgp_routeinfo/asnames"
+	"github.com/BelWue/bgp_routeinfo/log"
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// newTestClient returns a client connected to a server with one router,
+// which has a locally originated path for 192.0.2.0/24 in its RIB.
+func newTestClient(t *testing.T) *Client {
+	logger := &log.DefaultRouteInfoLogger{}
+	logger.DisableBgpLog()
+	bgpServer := server.NewBgpServer(server.LoggerOption(logger.GetBgpLogger()))
+	go bgpServer.Serve()
+	t.Cleanup(bgpServer.Stop)
+	if err := bgpServer.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{Asn: 64496, RouterId: "192.0.2.255", ListenPort: -1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	nlri, err := bgp.NewIPAddrPrefix(netip.MustParsePrefix("192.0.2.0/24"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nexthop, err := bgp.NewPathAttributeNextHop(netip.MustParseAddr("198.51.100.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := &apiutil.Path{Family: bgp.RF_IPv4_UC, Nlri: nlri, Attrs: []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{64500, 64501}),
+		}),
+		nexthop,
+	}}
+	if _, err := bgpServer.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{path}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := &routeinfo.RouteInfoServer{Routers: map[string]*routeinfo.Router{
+		"test": {Name: "test", Asn: 64496, GobgpServer: bgpServer, Logger: logger},
+	}}
+	listener := bufconn.Listen(1024 * 1024)
+	s := NewServer(rs)
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	client, err := NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestLookup(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	response, err := client.Lookup(ctx, &LookupRequest{Prefix: "192.0.2.1", Explain: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Errors) != 0 || len(response.Results) != 1 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	result := response.Results[0]
+	if result.Router != "test" || result.Prefix != "192.0.2.0/24" || len(result.Paths) != 1 ||
+		result.Paths[0].OriginAs != 64501 || result.Paths[0].NextHop != "198.51.100.1" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	response, err = client.Lookup(ctx, &LookupRequest{Routers: []string{"other"}, Prefix: "192.0.2.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Errors) != 1 || len(response.Results) != 0 {
+		t.Errorf("unexpected response for unknown router %+v", response)
+	}
+
+	batch, err := client.LookupBatch(ctx, &LookupBatchRequest{Prefixes: []string{"192.0.2.0/24", "198.51.100.1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.Responses) != 2 || len(batch.Responses[0].Results) != 1 || len(batch.Responses[1].Results) != 0 {
+		t.Errorf("unexpected batch response %+v", batch)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	status, err := client.Status(ctx, &StatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Results) != 1 || status.Results[0].Router != "test" || !status.Results[0].Ready {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	neighbors, err := client.Neighbors(ctx, &NeighborsRequest{Routers: []string{"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(neighbors.Errors) != 0 || len(neighbors.Results) != 1 || neighbors.Results[0].Router != "test" {
+		t.Errorf("unexpected neighbors %+v", neighbors)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Subscribe(ctx, &SubscribeRequest{Types: []string{string(routeinfo.EventNeighborDown)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := stream.Recv(); err == nil {
+		t.Error("canceled subscription received an event")
+	}
+}
+
+func TestPathConversion(t *testing.T) {
+	path := routeinfo.RouteInfo{
+		Prefix:               "192.0.2.0/24",
+		Peer:                 "198.51.100.1",
+		NextHop:              "198.51.100.2",
+		AsPath:               []uint32{64500, 64501},
+		OriginAs:             64501,
+		Origin:               routeinfo.Incomplete,
+		LocalPref:            100,
+		Med:                  10,
+		Best:                 true,
+		Communities:          []string{"64500:1"},
+		LargeCommunities:     []string{"64500:1:2"},
+		ExtendedCommunities:  []string{"rt:64500:3"},
+		AnnotatedCommunities: []routeinfo.AnnotatedCommunity{{Community: "64500:1", Description: "customer", Class: "info"}},
+		OriginatorId:         "192.0.2.254",
+		PathId:               7,
+		ClusterList:          []string{"192.0.2.253"},
+		RouterId:             "192.0.2.252",
+		Timestamp:            time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Validation:           bgp.VALIDATION_STATE_INVALID,
+		VRPs:                 []routeinfo.VRP{{Prefix: netip.MustParsePrefix("192.0.2.0/23"), MaxLength: 23, Asn: 64502}},
+		ASPA:                 &routeinfo.ASPAResult{State: routeinfo.ASPAInvalid, Hop: &routeinfo.ASPAHop{Customer: 64501, Provider: 64500}},
+		AsPathNames:          []asnames.Name{{Asn: 64500, Name: "TRANSIT", Country: "DE"}, {Asn: 64501}},
+		Bogon:                []routeinfo.BogonReason{{Kind: routeinfo.BogonASN, Match: "AS64500", Description: "documentation"}},
+		IRR:                  &routeinfo.IRRResult{Origins: []uint32{64502}, ASSet: "AS-EXAMPLE"},
+	}
+	encoded, err := proto.Marshal(NewPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Path{}
+	if err := proto.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if converted := decoded.ToRouteInfo(); !reflect.DeepEqual(converted, path) {
+		t.Errorf("path changed by conversion:\n%+v\n%+v", converted, path)
+	}
+
+	event := routeinfo.Event{Type: routeinfo.EventWithdrawn, Time: path.Timestamp, Router: "test", Prefix: path.Prefix, Path: &path}
+	if converted := NewEvent(event).ToRouteInfo(); !reflect.DeepEqual(converted, event) {
+		t.Errorf("event changed by conversion:\n%+v\n%+v", converted, event)
+	}
+	neighbor := routeinfo.NeighborStatus{Address: "198.51.100.1", Asn: 64500, State: "active", Received: 2, Accepted: 1}
+	if converted := NewNeighborStatus(neighbor).ToRouteInfo(); converted != neighbor {
+		t.Errorf("neighbor changed by conversion: %+v", converted)
+	}
+}
@@ -0,0 +1,1944 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: routeinfo.proto
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Origin int32
+
+const (
+	Origin_ORIGIN_IGP        Origin = 0
+	Origin_ORIGIN_EGP        Origin = 1
+	Origin_ORIGIN_INCOMPLETE Origin = 2
+	Origin_ORIGIN_UNKNOWN    Origin = 255
+)
+
+// Enum value maps for Origin.
+var (
+	Origin_name = map[int32]string{
+		0:   "ORIGIN_IGP",
+		1:   "ORIGIN_EGP",
+		2:   "ORIGIN_INCOMPLETE",
+		255: "ORIGIN_UNKNOWN",
+	}
+	Origin_value = map[string]int32{
+		"ORIGIN_IGP":        0,
+		"ORIGIN_EGP":        1,
+		"ORIGIN_INCOMPLETE": 2,
+		"ORIGIN_UNKNOWN":    255,
+	}
+)
+
+func (x Origin) Enum() *Origin {
+	p := new(Origin)
+	*p = x
+	return p
+}
+
+func (x Origin) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Origin) Descriptor() protoreflect.EnumDescriptor {
+	return file_routeinfo_proto_enumTypes[0].Descriptor()
+}
+
+func (Origin) Type() protoreflect.EnumType {
+	return &file_routeinfo_proto_enumTypes[0]
+}
+
+func (x Origin) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Origin.Descriptor instead.
+func (Origin) EnumDescriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{0}
+}
+
+// RPKI origin validation state, RFC 6811.
+type ValidationState int32
+
+const (
+	ValidationState_VALIDATION_STATE_VALID     ValidationState = 0
+	ValidationState_VALIDATION_STATE_NOT_FOUND ValidationState = 1
+	ValidationState_VALIDATION_STATE_INVALID   ValidationState = 2
+)
+
+// Enum value maps for ValidationState.
+var (
+	ValidationState_name = map[int32]string{
+		0: "VALIDATION_STATE_VALID",
+		1: "VALIDATION_STATE_NOT_FOUND",
+		2: "VALIDATION_STATE_INVALID",
+	}
+	ValidationState_value = map[string]int32{
+		"VALIDATION_STATE_VALID":     0,
+		"VALIDATION_STATE_NOT_FOUND": 1,
+		"VALIDATION_STATE_INVALID":   2,
+	}
+)
+
+func (x ValidationState) Enum() *ValidationState {
+	p := new(ValidationState)
+	*p = x
+	return p
+}
+
+func (x ValidationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_routeinfo_proto_enumTypes[1].Descriptor()
+}
+
+func (ValidationState) Type() protoreflect.EnumType {
+	return &file_routeinfo_proto_enumTypes[1]
+}
+
+func (x ValidationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidationState.Descriptor instead.
+func (ValidationState) EnumDescriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{1}
+}
+
+type ASPAState int32
+
+const (
+	ASPAState_ASPA_STATE_VALID   ASPAState = 0
+	ASPAState_ASPA_STATE_UNKNOWN ASPAState = 1
+	ASPAState_ASPA_STATE_INVALID ASPAState = 2
+)
+
+// Enum value maps for ASPAState.
+var (
+	ASPAState_name = map[int32]string{
+		0: "ASPA_STATE_VALID",
+		1: "ASPA_STATE_UNKNOWN",
+		2: "ASPA_STATE_INVALID",
+	}
+	ASPAState_value = map[string]int32{
+		"ASPA_STATE_VALID":   0,
+		"ASPA_STATE_UNKNOWN": 1,
+		"ASPA_STATE_INVALID": 2,
+	}
+)
+
+func (x ASPAState) Enum() *ASPAState {
+	p := new(ASPAState)
+	*p = x
+	return p
+}
+
+func (x ASPAState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ASPAState) Descriptor() protoreflect.EnumDescriptor {
+	return file_routeinfo_proto_enumTypes[2].Descriptor()
+}
+
+func (ASPAState) Type() protoreflect.EnumType {
+	return &file_routeinfo_proto_enumTypes[2]
+}
+
+func (x ASPAState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ASPAState.Descriptor instead.
+func (ASPAState) EnumDescriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{2}
+}
+
+type LookupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Routers to look up the prefix on, all if empty.
+	Routers []string `protobuf:"bytes,1,rep,name=routers,proto3" json:"routers,omitempty"`
+	Prefix  string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Explain for each path that is not the best path why it lost.
+	Explain       bool `protobuf:"varint,3,opt,name=explain,proto3" json:"explain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	mi := &file_routeinfo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LookupRequest) GetRouters() []string {
+	if x != nil {
+		return x.Routers
+	}
+	return nil
+}
+
+func (x *LookupRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *LookupRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+type LookupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []string               `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	Results       []*PrefixResult        `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResponse) Reset() {
+	*x = LookupResponse{}
+	mi := &file_routeinfo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResponse) ProtoMessage() {}
+
+func (x *LookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResponse.ProtoReflect.Descriptor instead.
+func (*LookupResponse) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *LookupResponse) GetResults() []*PrefixResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type PrefixResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Router        string                 `protobuf:"bytes,1,opt,name=router,proto3" json:"router,omitempty"`
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Paths         []*Path                `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+	Explanation   []*PathDecision        `protobuf:"bytes,4,rep,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixResult) Reset() {
+	*x = PrefixResult{}
+	mi := &file_routeinfo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixResult) ProtoMessage() {}
+
+func (x *PrefixResult) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixResult.ProtoReflect.Descriptor instead.
+func (*PrefixResult) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PrefixResult) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+func (x *PrefixResult) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PrefixResult) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *PrefixResult) GetExplanation() []*PathDecision {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+type LookupBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Routers       []string               `protobuf:"bytes,1,rep,name=routers,proto3" json:"routers,omitempty"`
+	Prefixes      []string               `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupBatchRequest) Reset() {
+	*x = LookupBatchRequest{}
+	mi := &file_routeinfo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupBatchRequest) ProtoMessage() {}
+
+func (x *LookupBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupBatchRequest.ProtoReflect.Descriptor instead.
+func (*LookupBatchRequest) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LookupBatchRequest) GetRouters() []string {
+	if x != nil {
+		return x.Routers
+	}
+	return nil
+}
+
+func (x *LookupBatchRequest) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+type LookupBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Responses for each of the prefixes, in the order of the request.
+	Responses     []*LookupResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupBatchResponse) Reset() {
+	*x = LookupBatchResponse{}
+	mi := &file_routeinfo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupBatchResponse) ProtoMessage() {}
+
+func (x *LookupBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupBatchResponse.ProtoReflect.Descriptor instead.
+func (*LookupBatchResponse) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LookupBatchResponse) GetResponses() []*LookupResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	mi := &file_routeinfo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{5}
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []string               `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	Results       []*RouterStatus        `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	mi := &file_routeinfo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StatusResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetResults() []*RouterStatus {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type RouterStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Router        string                 `protobuf:"bytes,1,opt,name=router,proto3" json:"router,omitempty"`
+	Ready         bool                   `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouterStatus) Reset() {
+	*x = RouterStatus{}
+	mi := &file_routeinfo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouterStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouterStatus) ProtoMessage() {}
+
+func (x *RouterStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouterStatus.ProtoReflect.Descriptor instead.
+func (*RouterStatus) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RouterStatus) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+func (x *RouterStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type NeighborsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Routers       []string               `protobuf:"bytes,1,rep,name=routers,proto3" json:"routers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NeighborsRequest) Reset() {
+	*x = NeighborsRequest{}
+	mi := &file_routeinfo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NeighborsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborsRequest) ProtoMessage() {}
+
+func (x *NeighborsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborsRequest.ProtoReflect.Descriptor instead.
+func (*NeighborsRequest) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NeighborsRequest) GetRouters() []string {
+	if x != nil {
+		return x.Routers
+	}
+	return nil
+}
+
+type NeighborsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []string               `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	Results       []*RouterNeighbors     `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NeighborsResponse) Reset() {
+	*x = NeighborsResponse{}
+	mi := &file_routeinfo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NeighborsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborsResponse) ProtoMessage() {}
+
+func (x *NeighborsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborsResponse.ProtoReflect.Descriptor instead.
+func (*NeighborsResponse) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NeighborsResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *NeighborsResponse) GetResults() []*RouterNeighbors {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type RouterNeighbors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Router        string                 `protobuf:"bytes,1,opt,name=router,proto3" json:"router,omitempty"`
+	Neighbors     []*NeighborStatus      `protobuf:"bytes,2,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouterNeighbors) Reset() {
+	*x = RouterNeighbors{}
+	mi := &file_routeinfo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouterNeighbors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouterNeighbors) ProtoMessage() {}
+
+func (x *RouterNeighbors) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouterNeighbors.ProtoReflect.Descriptor instead.
+func (*RouterNeighbors) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RouterNeighbors) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+func (x *RouterNeighbors) GetNeighbors() []*NeighborStatus {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+type NeighborStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Asn     uint32                 `protobuf:"varint,2,opt,name=asn,proto3" json:"asn,omitempty"`
+	State   string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// When the session was established, or went down if it is not.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Received      uint64                 `protobuf:"varint,5,opt,name=received,proto3" json:"received,omitempty"`
+	Accepted      uint64                 `protobuf:"varint,6,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NeighborStatus) Reset() {
+	*x = NeighborStatus{}
+	mi := &file_routeinfo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NeighborStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborStatus) ProtoMessage() {}
+
+func (x *NeighborStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborStatus.ProtoReflect.Descriptor instead.
+func (*NeighborStatus) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NeighborStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NeighborStatus) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *NeighborStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *NeighborStatus) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *NeighborStatus) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *NeighborStatus) GetAccepted() uint64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Routers whose events are sent, all if empty.
+	Routers []string `protobuf:"bytes,1,rep,name=routers,proto3" json:"routers,omitempty"`
+	// Types of events to send, i.e. "rpki-invalid", all if empty.
+	Types         []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_routeinfo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubscribeRequest) GetRouters() []string {
+	if x != nil {
+		return x.Routers
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type Event struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Router  string                 `protobuf:"bytes,3,opt,name=router,proto3" json:"router,omitempty"`
+	Prefix  string                 `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Message string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// The path which caused the event, if any.
+	Path          *Path `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_routeinfo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+func (x *Event) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetPath() *Path {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type Path struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Prefix               string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Peer                 string                 `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	NextHop              string                 `protobuf:"bytes,3,opt,name=next_hop,json=nextHop,proto3" json:"next_hop,omitempty"`
+	AsPath               []uint32               `protobuf:"varint,4,rep,packed,name=as_path,json=asPath,proto3" json:"as_path,omitempty"`
+	OriginAs             uint32                 `protobuf:"varint,5,opt,name=origin_as,json=originAs,proto3" json:"origin_as,omitempty"`
+	Origin               Origin                 `protobuf:"varint,6,opt,name=origin,proto3,enum=routeinfo.Origin" json:"origin,omitempty"`
+	LocalPref            uint32                 `protobuf:"varint,7,opt,name=local_pref,json=localPref,proto3" json:"local_pref,omitempty"`
+	Med                  uint32                 `protobuf:"varint,8,opt,name=med,proto3" json:"med,omitempty"`
+	Best                 bool                   `protobuf:"varint,9,opt,name=best,proto3" json:"best,omitempty"`
+	Communities          []string               `protobuf:"bytes,10,rep,name=communities,proto3" json:"communities,omitempty"`
+	LargeCommunities     []string               `protobuf:"bytes,11,rep,name=large_communities,json=largeCommunities,proto3" json:"large_communities,omitempty"`
+	ExtendedCommunities  []string               `protobuf:"bytes,12,rep,name=extended_communities,json=extendedCommunities,proto3" json:"extended_communities,omitempty"`
+	AnnotatedCommunities []*AnnotatedCommunity  `protobuf:"bytes,13,rep,name=annotated_communities,json=annotatedCommunities,proto3" json:"annotated_communities,omitempty"`
+	OriginatorId         string                 `protobuf:"bytes,14,opt,name=originator_id,json=originatorId,proto3" json:"originator_id,omitempty"`
+	ClusterList          []string               `protobuf:"bytes,15,rep,name=cluster_list,json=clusterList,proto3" json:"cluster_list,omitempty"`
+	RouterId             string                 `protobuf:"bytes,16,opt,name=router_id,json=routerId,proto3" json:"router_id,omitempty"`
+	Timestamp            *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Validation           ValidationState        `protobuf:"varint,18,opt,name=validation,proto3,enum=routeinfo.ValidationState" json:"validation,omitempty"`
+	Vrps                 []*VRP                 `protobuf:"bytes,19,rep,name=vrps,proto3" json:"vrps,omitempty"`
+	Aspa                 *ASPAResult            `protobuf:"bytes,20,opt,name=aspa,proto3" json:"aspa,omitempty"`
+	AsPathNames          []*ASName              `protobuf:"bytes,21,rep,name=as_path_names,json=asPathNames,proto3" json:"as_path_names,omitempty"`
+	Bogon                []*BogonReason         `protobuf:"bytes,22,rep,name=bogon,proto3" json:"bogon,omitempty"`
+	Irr                  *IRRResult             `protobuf:"bytes,23,opt,name=irr,proto3" json:"irr,omitempty"`
+	PathId               uint32                 `protobuf:"varint,24,opt,name=path_id,json=pathId,proto3" json:"path_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	mi := &file_routeinfo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Path) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *Path) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *Path) GetNextHop() string {
+	if x != nil {
+		return x.NextHop
+	}
+	return ""
+}
+
+func (x *Path) GetAsPath() []uint32 {
+	if x != nil {
+		return x.AsPath
+	}
+	return nil
+}
+
+func (x *Path) GetOriginAs() uint32 {
+	if x != nil {
+		return x.OriginAs
+	}
+	return 0
+}
+
+func (x *Path) GetOrigin() Origin {
+	if x != nil {
+		return x.Origin
+	}
+	return Origin_ORIGIN_IGP
+}
+
+func (x *Path) GetLocalPref() uint32 {
+	if x != nil {
+		return x.LocalPref
+	}
+	return 0
+}
+
+func (x *Path) GetMed() uint32 {
+	if x != nil {
+		return x.Med
+	}
+	return 0
+}
+
+func (x *Path) GetBest() bool {
+	if x != nil {
+		return x.Best
+	}
+	return false
+}
+
+func (x *Path) GetCommunities() []string {
+	if x != nil {
+		return x.Communities
+	}
+	return nil
+}
+
+func (x *Path) GetLargeCommunities() []string {
+	if x != nil {
+		return x.LargeCommunities
+	}
+	return nil
+}
+
+func (x *Path) GetExtendedCommunities() []string {
+	if x != nil {
+		return x.ExtendedCommunities
+	}
+	return nil
+}
+
+func (x *Path) GetAnnotatedCommunities() []*AnnotatedCommunity {
+	if x != nil {
+		return x.AnnotatedCommunities
+	}
+	return nil
+}
+
+func (x *Path) GetOriginatorId() string {
+	if x != nil {
+		return x.OriginatorId
+	}
+	return ""
+}
+
+func (x *Path) GetClusterList() []string {
+	if x != nil {
+		return x.ClusterList
+	}
+	return nil
+}
+
+func (x *Path) GetRouterId() string {
+	if x != nil {
+		return x.RouterId
+	}
+	return ""
+}
+
+func (x *Path) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Path) GetValidation() ValidationState {
+	if x != nil {
+		return x.Validation
+	}
+	return ValidationState_VALIDATION_STATE_VALID
+}
+
+func (x *Path) GetVrps() []*VRP {
+	if x != nil {
+		return x.Vrps
+	}
+	return nil
+}
+
+func (x *Path) GetAspa() *ASPAResult {
+	if x != nil {
+		return x.Aspa
+	}
+	return nil
+}
+
+func (x *Path) GetAsPathNames() []*ASName {
+	if x != nil {
+		return x.AsPathNames
+	}
+	return nil
+}
+
+func (x *Path) GetBogon() []*BogonReason {
+	if x != nil {
+		return x.Bogon
+	}
+	return nil
+}
+
+func (x *Path) GetIrr() *IRRResult {
+	if x != nil {
+		return x.Irr
+	}
+	return nil
+}
+
+func (x *Path) GetPathId() uint32 {
+	if x != nil {
+		return x.PathId
+	}
+	return 0
+}
+
+type AnnotatedCommunity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Community     string                 `protobuf:"bytes,1,opt,name=community,proto3" json:"community,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Class         string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnnotatedCommunity) Reset() {
+	*x = AnnotatedCommunity{}
+	mi := &file_routeinfo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnotatedCommunity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnotatedCommunity) ProtoMessage() {}
+
+func (x *AnnotatedCommunity) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnotatedCommunity.ProtoReflect.Descriptor instead.
+func (*AnnotatedCommunity) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AnnotatedCommunity) GetCommunity() string {
+	if x != nil {
+		return x.Community
+	}
+	return ""
+}
+
+func (x *AnnotatedCommunity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AnnotatedCommunity) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+type VRP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MaxLength     uint32                 `protobuf:"varint,2,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	Asn           uint32                 `protobuf:"varint,3,opt,name=asn,proto3" json:"asn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VRP) Reset() {
+	*x = VRP{}
+	mi := &file_routeinfo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VRP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VRP) ProtoMessage() {}
+
+func (x *VRP) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VRP.ProtoReflect.Descriptor instead.
+func (*VRP) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VRP) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *VRP) GetMaxLength() uint32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *VRP) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+type ASPAResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	State      ASPAState              `protobuf:"varint,1,opt,name=state,proto3,enum=routeinfo.ASPAState" json:"state,omitempty"`
+	Downstream bool                   `protobuf:"varint,2,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	// The first hop which is not a customer-provider relation, set for
+	// invalid paths.
+	Hop           *ASPAHop `protobuf:"bytes,3,opt,name=hop,proto3" json:"hop,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ASPAResult) Reset() {
+	*x = ASPAResult{}
+	mi := &file_routeinfo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ASPAResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ASPAResult) ProtoMessage() {}
+
+func (x *ASPAResult) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ASPAResult.ProtoReflect.Descriptor instead.
+func (*ASPAResult) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ASPAResult) GetState() ASPAState {
+	if x != nil {
+		return x.State
+	}
+	return ASPAState_ASPA_STATE_VALID
+}
+
+func (x *ASPAResult) GetDownstream() bool {
+	if x != nil {
+		return x.Downstream
+	}
+	return false
+}
+
+func (x *ASPAResult) GetHop() *ASPAHop {
+	if x != nil {
+		return x.Hop
+	}
+	return nil
+}
+
+type ASPAHop struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      uint32                 `protobuf:"varint,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	Provider      uint32                 `protobuf:"varint,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ASPAHop) Reset() {
+	*x = ASPAHop{}
+	mi := &file_routeinfo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ASPAHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ASPAHop) ProtoMessage() {}
+
+func (x *ASPAHop) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ASPAHop.ProtoReflect.Descriptor instead.
+func (*ASPAHop) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ASPAHop) GetCustomer() uint32 {
+	if x != nil {
+		return x.Customer
+	}
+	return 0
+}
+
+func (x *ASPAHop) GetProvider() uint32 {
+	if x != nil {
+		return x.Provider
+	}
+	return 0
+}
+
+type ASName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asn           uint32                 `protobuf:"varint,1,opt,name=asn,proto3" json:"asn,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Organization  string                 `protobuf:"bytes,3,opt,name=organization,proto3" json:"organization,omitempty"`
+	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Registry      string                 `protobuf:"bytes,5,opt,name=registry,proto3" json:"registry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ASName) Reset() {
+	*x = ASName{}
+	mi := &file_routeinfo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ASName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ASName) ProtoMessage() {}
+
+func (x *ASName) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ASName.ProtoReflect.Descriptor instead.
+func (*ASName) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ASName) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *ASName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ASName) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *ASName) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ASName) GetRegistry() string {
+	if x != nil {
+		return x.Registry
+	}
+	return ""
+}
+
+type BogonReason struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "special-purpose", "unallocated" or "asn"
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Match         string `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BogonReason) Reset() {
+	*x = BogonReason{}
+	mi := &file_routeinfo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BogonReason) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BogonReason) ProtoMessage() {}
+
+func (x *BogonReason) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BogonReason.ProtoReflect.Descriptor instead.
+func (*BogonReason) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BogonReason) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BogonReason) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *BogonReason) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type IRRResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RouteObject   bool                   `protobuf:"varint,1,opt,name=route_object,json=routeObject,proto3" json:"route_object,omitempty"`
+	Origins       []uint32               `protobuf:"varint,2,rep,packed,name=origins,proto3" json:"origins,omitempty"`
+	AsSet         string                 `protobuf:"bytes,3,opt,name=as_set,json=asSet,proto3" json:"as_set,omitempty"`
+	InAsSet       bool                   `protobuf:"varint,4,opt,name=in_as_set,json=inAsSet,proto3" json:"in_as_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IRRResult) Reset() {
+	*x = IRRResult{}
+	mi := &file_routeinfo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IRRResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IRRResult) ProtoMessage() {}
+
+func (x *IRRResult) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IRRResult.ProtoReflect.Descriptor instead.
+func (*IRRResult) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IRRResult) GetRouteObject() bool {
+	if x != nil {
+		return x.RouteObject
+	}
+	return false
+}
+
+func (x *IRRResult) GetOrigins() []uint32 {
+	if x != nil {
+		return x.Origins
+	}
+	return nil
+}
+
+func (x *IRRResult) GetAsSet() string {
+	if x != nil {
+		return x.AsSet
+	}
+	return ""
+}
+
+func (x *IRRResult) GetInAsSet() bool {
+	if x != nil {
+		return x.InAsSet
+	}
+	return false
+}
+
+type PathDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Path  *Path                  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The step of the best path selection the path lost at, i.e. "local-pref".
+	LostAt        string `protobuf:"bytes,2,opt,name=lost_at,json=lostAt,proto3" json:"lost_at,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathDecision) Reset() {
+	*x = PathDecision{}
+	mi := &file_routeinfo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathDecision) ProtoMessage() {}
+
+func (x *PathDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_routeinfo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathDecision.ProtoReflect.Descriptor instead.
+func (*PathDecision) Descriptor() ([]byte, []int) {
+	return file_routeinfo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PathDecision) GetPath() *Path {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PathDecision) GetLostAt() string {
+	if x != nil {
+		return x.LostAt
+	}
+	return ""
+}
+
+func (x *PathDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+var File_routeinfo_proto protoreflect.FileDescriptor
+
+const file_routeinfo_proto_rawDesc = "" +
+	"\n" +
+	"\x0frouteinfo.proto\x12\trouteinfo\x1a\x1fgoogle/protobuf/timestamp.proto\"[\n" +
+	"\rLookupRequest\x12\x18\n" +
+	"\arouters\x18\x01 \x03(\tR\arouters\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x18\n" +
+	"\aexplain\x18\x03 \x01(\bR\aexplain\"[\n" +
+	"\x0eLookupResponse\x12\x16\n" +
+	"\x06errors\x18\x01 \x03(\tR\x06errors\x121\n" +
+	"\aresults\x18\x02 \x03(\v2\x17.routeinfo.PrefixResultR\aresults\"\xa0\x01\n" +
+	"\fPrefixResult\x12\x16\n" +
+	"\x06router\x18\x01 \x01(\tR\x06router\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12%\n" +
+	"\x05paths\x18\x03 \x03(\v2\x0f.routeinfo.PathR\x05paths\x129\n" +
+	"\vexplanation\x18\x04 \x03(\v2\x17.routeinfo.PathDecisionR\vexplanation\"J\n" +
+	"\x12LookupBatchRequest\x12\x18\n" +
+	"\arouters\x18\x01 \x03(\tR\arouters\x12\x1a\n" +
+	"\bprefixes\x18\x02 \x03(\tR\bprefixes\"N\n" +
+	"\x13LookupBatchResponse\x127\n" +
+	"\tresponses\x18\x01 \x03(\v2\x19.routeinfo.LookupResponseR\tresponses\"\x0f\n" +
+	"\rStatusRequest\"[\n" +
+	"\x0eStatusResponse\x12\x16\n" +
+	"\x06errors\x18\x01 \x03(\tR\x06errors\x121\n" +
+	"\aresults\x18\x02 \x03(\v2\x17.routeinfo.RouterStatusR\aresults\"<\n" +
+	"\fRouterStatus\x12\x16\n" +
+	"\x06router\x18\x01 \x01(\tR\x06router\x12\x14\n" +
+	"\x05ready\x18\x02 \x01(\bR\x05ready\",\n" +
+	"\x10NeighborsRequest\x12\x18\n" +
+	"\arouters\x18\x01 \x03(\tR\arouters\"a\n" +
+	"\x11NeighborsResponse\x12\x16\n" +
+	"\x06errors\x18\x01 \x03(\tR\x06errors\x124\n" +
+	"\aresults\x18\x02 \x03(\v2\x1a.routeinfo.RouterNeighborsR\aresults\"b\n" +
+	"\x0fRouterNeighbors\x12\x16\n" +
+	"\x06router\x18\x01 \x01(\tR\x06router\x127\n" +
+	"\tneighbors\x18\x02 \x03(\v2\x19.routeinfo.NeighborStatusR\tneighbors\"\xbc\x01\n" +
+	"\x0eNeighborStatus\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x10\n" +
+	"\x03asn\x18\x02 \x01(\rR\x03asn\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x120\n" +
+	"\x05since\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x1a\n" +
+	"\breceived\x18\x05 \x01(\x04R\breceived\x12\x1a\n" +
+	"\baccepted\x18\x06 \x01(\x04R\baccepted\"B\n" +
+	"\x10SubscribeRequest\x12\x18\n" +
+	"\arouters\x18\x01 \x03(\tR\arouters\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\"\xba\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06router\x18\x03 \x01(\tR\x06router\x12\x16\n" +
+	"\x06prefix\x18\x04 \x01(\tR\x06prefix\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12#\n" +
+	"\x04path\x18\x06 \x01(\v2\x0f.routeinfo.PathR\x04path\"\x99\a\n" +
+	"\x04Path\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04peer\x18\x02 \x01(\tR\x04peer\x12\x19\n" +
+	"\bnext_hop\x18\x03 \x01(\tR\anextHop\x12\x17\n" +
+	"\aas_path\x18\x04 \x03(\rR\x06asPath\x12\x1b\n" +
+	"\torigin_as\x18\x05 \x01(\rR\boriginAs\x12)\n" +
+	"\x06origin\x18\x06 \x01(\x0e2\x11.routeinfo.OriginR\x06origin\x12\x1d\n" +
+	"\n" +
+	"local_pref\x18\a \x01(\rR\tlocalPref\x12\x10\n" +
+	"\x03med\x18\b \x01(\rR\x03med\x12\x12\n" +
+	"\x04best\x18\t \x01(\bR\x04best\x12 \n" +
+	"\vcommunities\x18\n" +
+	" \x03(\tR\vcommunities\x12+\n" +
+	"\x11large_communities\x18\v \x03(\tR\x10largeCommunities\x121\n" +
+	"\x14extended_communities\x18\f \x03(\tR\x13extendedCommunities\x12R\n" +
+	"\x15annotated_communities\x18\r \x03(\v2\x1d.routeinfo.AnnotatedCommunityR\x14annotatedCommunities\x12#\n" +
+	"\roriginator_id\x18\x0e \x01(\tR\foriginatorId\x12!\n" +
+	"\fcluster_list\x18\x0f \x03(\tR\vclusterList\x12\x1b\n" +
+	"\trouter_id\x18\x10 \x01(\tR\brouterId\x128\n" +
+	"\ttimestamp\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12:\n" +
+	"\n" +
+	"validation\x18\x12 \x01(\x0e2\x1a.routeinfo.ValidationStateR\n" +
+	"validation\x12\"\n" +
+	"\x04vrps\x18\x13 \x03(\v2\x0e.routeinfo.VRPR\x04vrps\x12)\n" +
+	"\x04aspa\x18\x14 \x01(\v2\x15.routeinfo.ASPAResultR\x04aspa\x125\n" +
+	"\ras_path_names\x18\x15 \x03(\v2\x11.routeinfo.ASNameR\vasPathNames\x12,\n" +
+	"\x05bogon\x18\x16 \x03(\v2\x16.routeinfo.BogonReasonR\x05bogon\x12&\n" +
+	"\x03irr\x18\x17 \x01(\v2\x14.routeinfo.IRRResultR\x03irr\x12\x17\n" +
+	"\apath_id\x18\x18 \x01(\rR\x06pathId\"j\n" +
+	"\x12AnnotatedCommunity\x12\x1c\n" +
+	"\tcommunity\x18\x01 \x01(\tR\tcommunity\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05class\x18\x03 \x01(\tR\x05class\"N\n" +
+	"\x03VRP\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x02 \x01(\rR\tmaxLength\x12\x10\n" +
+	"\x03asn\x18\x03 \x01(\rR\x03asn\"~\n" +
+	"\n" +
+	"ASPAResult\x12*\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x14.routeinfo.ASPAStateR\x05state\x12\x1e\n" +
+	"\n" +
+	"downstream\x18\x02 \x01(\bR\n" +
+	"downstream\x12$\n" +
+	"\x03hop\x18\x03 \x01(\v2\x12.routeinfo.ASPAHopR\x03hop\"A\n" +
+	"\aASPAHop\x12\x1a\n" +
+	"\bcustomer\x18\x01 \x01(\rR\bcustomer\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\rR\bprovider\"\x88\x01\n" +
+	"\x06ASName\x12\x10\n" +
+	"\x03asn\x18\x01 \x01(\rR\x03asn\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\forganization\x18\x03 \x01(\tR\forganization\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x1a\n" +
+	"\bregistry\x18\x05 \x01(\tR\bregistry\"Y\n" +
+	"\vBogonReason\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05match\x18\x02 \x01(\tR\x05match\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"{\n" +
+	"\tIRRResult\x12!\n" +
+	"\froute_object\x18\x01 \x01(\bR\vrouteObject\x12\x18\n" +
+	"\aorigins\x18\x02 \x03(\rR\aorigins\x12\x15\n" +
+	"\x06as_set\x18\x03 \x01(\tR\x05asSet\x12\x1a\n" +
+	"\tin_as_set\x18\x04 \x01(\bR\ainAsSet\"d\n" +
+	"\fPathDecision\x12#\n" +
+	"\x04path\x18\x01 \x01(\v2\x0f.routeinfo.PathR\x04path\x12\x17\n" +
+	"\alost_at\x18\x02 \x01(\tR\x06lostAt\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason*T\n" +
+	"\x06Origin\x12\x0e\n" +
+	"\n" +
+	"ORIGIN_IGP\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ORIGIN_EGP\x10\x01\x12\x15\n" +
+	"\x11ORIGIN_INCOMPLETE\x10\x02\x12\x13\n" +
+	"\x0eORIGIN_UNKNOWN\x10\xff\x01*k\n" +
+	"\x0fValidationState\x12\x1a\n" +
+	"\x16VALIDATION_STATE_VALID\x10\x00\x12\x1e\n" +
+	"\x1aVALIDATION_STATE_NOT_FOUND\x10\x01\x12\x1c\n" +
+	"\x18VALIDATION_STATE_INVALID\x10\x02*Q\n" +
+	"\tASPAState\x12\x14\n" +
+	"\x10ASPA_STATE_VALID\x10\x00\x12\x16\n" +
+	"\x12ASPA_STATE_UNKNOWN\x10\x01\x12\x16\n" +
+	"\x12ASPA_STATE_INVALID\x10\x022\xdd\x02\n" +
+	"\tRouteInfo\x12=\n" +
+	"\x06Lookup\x12\x18.routeinfo.LookupRequest\x1a\x19.routeinfo.LookupResponse\x12L\n" +
+	"\vLookupBatch\x12\x1d.routeinfo.LookupBatchRequest\x1a\x1e.routeinfo.LookupBatchResponse\x12=\n" +
+	"\x06Status\x12\x18.routeinfo.StatusRequest\x1a\x19.routeinfo.StatusResponse\x12F\n" +
+	"\tNeighbors\x12\x1b.routeinfo.NeighborsRequest\x1a\x1c.routeinfo.NeighborsResponse\x12<\n" +
+	"\tSubscribe\x12\x1b.routeinfo.SubscribeRequest\x1a\x10.routeinfo.Event0\x01B)Z'github.com/BelWue/bgp_routeinfo/grpcapib\x06proto3"
+
+var (
+	file_routeinfo_proto_rawDescOnce sync.Once
+	file_routeinfo_proto_rawDescData []byte
+)
+
+func file_routeinfo_proto_rawDescGZIP() []byte {
+	file_routeinfo_proto_rawDescOnce.Do(func() {
+		file_routeinfo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_routeinfo_proto_rawDesc), len(file_routeinfo_proto_rawDesc)))
+	})
+	return file_routeinfo_proto_rawDescData
+}
+
+var file_routeinfo_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_routeinfo_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_routeinfo_proto_goTypes = []any{
+	(Origin)(0),                   // 0: routeinfo.Origin
+	(ValidationState)(0),          // 1: routeinfo.ValidationState
+	(ASPAState)(0),                // 2: routeinfo.ASPAState
+	(*LookupRequest)(nil),         // 3: routeinfo.LookupRequest
+	(*LookupResponse)(nil),        // 4: routeinfo.LookupResponse
+	(*PrefixResult)(nil),          // 5: routeinfo.PrefixResult
+	(*LookupBatchRequest)(nil),    // 6: routeinfo.LookupBatchRequest
+	(*LookupBatchResponse)(nil),   // 7: routeinfo.LookupBatchResponse
+	(*StatusRequest)(nil),         // 8: routeinfo.StatusRequest
+	(*StatusResponse)(nil),        // 9: routeinfo.StatusResponse
+	(*RouterStatus)(nil),          // 10: routeinfo.RouterStatus
+	(*NeighborsRequest)(nil),      // 11: routeinfo.NeighborsRequest
+	(*NeighborsResponse)(nil),     // 12: routeinfo.NeighborsResponse
+	(*RouterNeighbors)(nil),       // 13: routeinfo.RouterNeighbors
+	(*NeighborStatus)(nil),        // 14: routeinfo.NeighborStatus
+	(*SubscribeRequest)(nil),      // 15: routeinfo.SubscribeRequest
+	(*Event)(nil),                 // 16: routeinfo.Event
+	(*Path)(nil),                  // 17: routeinfo.Path
+	(*AnnotatedCommunity)(nil),    // 18: routeinfo.AnnotatedCommunity
+	(*VRP)(nil),                   // 19: routeinfo.VRP
+	(*ASPAResult)(nil),            // 20: routeinfo.ASPAResult
+	(*ASPAHop)(nil),               // 21: routeinfo.ASPAHop
+	(*ASName)(nil),                // 22: routeinfo.ASName
+	(*BogonReason)(nil),           // 23: routeinfo.BogonReason
+	(*IRRResult)(nil),             // 24: routeinfo.IRRResult
+	(*PathDecision)(nil),          // 25: routeinfo.PathDecision
+	(*timestamppb.Timestamp)(nil), // 26: google.protobuf.Timestamp
+}
+var file_routeinfo_proto_depIdxs = []int32{
+	5,  // 0: routeinfo.LookupResponse.results:type_name -> routeinfo.PrefixResult
+	17, // 1: routeinfo.PrefixResult.paths:type_name -> routeinfo.Path
+	25, // 2: routeinfo.PrefixResult.explanation:type_name -> routeinfo.PathDecision
+	4,  // 3: routeinfo.LookupBatchResponse.responses:type_name -> routeinfo.LookupResponse
+	10, // 4: routeinfo.StatusResponse.results:type_name -> routeinfo.RouterStatus
+	13, // 5: routeinfo.NeighborsResponse.results:type_name -> routeinfo.RouterNeighbors
+	14, // 6: routeinfo.RouterNeighbors.neighbors:type_name -> routeinfo.NeighborStatus
+	26, // 7: routeinfo.NeighborStatus.since:type_name -> google.protobuf.Timestamp
+	26, // 8: routeinfo.Event.time:type_name -> google.protobuf.Timestamp
+	17, // 9: routeinfo.Event.path:type_name -> routeinfo.Path
+	0,  // 10: routeinfo.Path.origin:type_name -> routeinfo.Origin
+	18, // 11: routeinfo.Path.annotated_communities:type_name -> routeinfo.AnnotatedCommunity
+	26, // 12: routeinfo.Path.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 13: routeinfo.Path.validation:type_name -> routeinfo.ValidationState
+	19, // 14: routeinfo.Path.vrps:type_name -> routeinfo.VRP
+	20, // 15: routeinfo.Path.aspa:type_name -> routeinfo.ASPAResult
+	22, // 16: routeinfo.Path.as_path_names:type_name -> routeinfo.ASName
+	23, // 17: routeinfo.Path.bogon:type_name -> routeinfo.BogonReason
+	24, // 18: routeinfo.Path.irr:type_name -> routeinfo.IRRResult
+	2,  // 19: routeinfo.ASPAResult.state:type_name -> routeinfo.ASPAState
+	21, // 20: routeinfo.ASPAResult.hop:type_name -> routeinfo.ASPAHop
+	17, // 21: routeinfo.PathDecision.path:type_name -> routeinfo.Path
+	3,  // 22: routeinfo.RouteInfo.Lookup:input_type -> routeinfo.LookupRequest
+	6,  // 23: routeinfo.RouteInfo.LookupBatch:input_type -> routeinfo.LookupBatchRequest
+	8,  // 24: routeinfo.RouteInfo.Status:input_type -> routeinfo.StatusRequest
+	11, // 25: routeinfo.RouteInfo.Neighbors:input_type -> routeinfo.NeighborsRequest
+	15, // 26: routeinfo.RouteInfo.Subscribe:input_type -> routeinfo.SubscribeRequest
+	4,  // 27: routeinfo.RouteInfo.Lookup:output_type -> routeinfo.LookupResponse
+	7,  // 28: routeinfo.RouteInfo.LookupBatch:output_type -> routeinfo.LookupBatchResponse
+	9,  // 29: routeinfo.RouteInfo.Status:output_type -> routeinfo.StatusResponse
+	12, // 30: routeinfo.RouteInfo.Neighbors:output_type -> routeinfo.NeighborsResponse
+	16, // 31: routeinfo.RouteInfo.Subscribe:output_type -> routeinfo.Event
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_routeinfo_proto_init() }
+func file_routeinfo_proto_init() {
+	if File_routeinfo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_routeinfo_proto_rawDesc), len(file_routeinfo_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_routeinfo_proto_goTypes,
+		DependencyIndexes: file_routeinfo_proto_depIdxs,
+		EnumInfos:         file_routeinfo_proto_enumTypes,
+		MessageInfos:      file_routeinfo_proto_msgTypes,
+	}.Build()
+	File_routeinfo_proto = out.File
+	file_routeinfo_proto_goTypes = nil
+	file_routeinfo_proto_depIdxs = nil
+}
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: routeinfo.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RouteInfo_Lookup_FullMethodName      = "/routeinfo.RouteInfo/Lookup"
+	RouteInfo_LookupBatch_FullMethodName = "/routeinfo.RouteInfo/LookupBatch"
+	RouteInfo_Status_FullMethodName      = "/routeinfo.RouteInfo/Status"
+	RouteInfo_Neighbors_FullMethodName   = "/routeinfo.RouteInfo/Neighbors"
+	RouteInfo_Subscribe_FullMethodName   = "/routeinfo.RouteInfo/Subscribe"
+)
+
+// RouteInfoClient is the client API for RouteInfo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RouteInfo mirrors the HTTP endpoints of routeinfo_server. Paths carry the
+// same information as routeinfo.RouteInfo in the Go package.
+type RouteInfoClient interface {
+	// Lookup returns the paths of the longest matching prefix of an address,
+	// or of the exact prefix, on each router.
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// LookupBatch looks up many prefixes with a single call.
+	LookupBatch(ctx context.Context, in *LookupBatchRequest, opts ...grpc.CallOption) (*LookupBatchResponse, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Neighbors(ctx context.Context, in *NeighborsRequest, opts ...grpc.CallOption) (*NeighborsResponse, error)
+	// Subscribe sends events until the client cancels the call.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type routeInfoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRouteInfoClient(cc grpc.ClientConnInterface) RouteInfoClient {
+	return &routeInfoClient{cc}
+}
+
+func (c *routeInfoClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, RouteInfo_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeInfoClient) LookupBatch(ctx context.Context, in *LookupBatchRequest, opts ...grpc.CallOption) (*LookupBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupBatchResponse)
+	err := c.cc.Invoke(ctx, RouteInfo_LookupBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeInfoClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, RouteInfo_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeInfoClient) Neighbors(ctx context.Context, in *NeighborsRequest, opts ...grpc.CallOption) (*NeighborsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NeighborsResponse)
+	err := c.cc.Invoke(ctx, RouteInfo_Neighbors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routeInfoClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RouteInfo_ServiceDesc.Streams[0], RouteInfo_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouteInfo_SubscribeClient = grpc.ServerStreamingClient[Event]
+
+// RouteInfoServer is the server API for RouteInfo service.
+// All implementations must embed UnimplementedRouteInfoServer
+// for forward compatibility.
+//
+// RouteInfo mirrors the HTTP endpoints of routeinfo_server. Paths carry the
+// same information as routeinfo.RouteInfo in the Go package.
+type RouteInfoServer interface {
+	// Lookup returns the paths of the longest matching prefix of an address,
+	// or of the exact prefix, on each router.
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// LookupBatch looks up many prefixes with a single call.
+	LookupBatch(context.Context, *LookupBatchRequest) (*LookupBatchResponse, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	Neighbors(context.Context, *NeighborsRequest) (*NeighborsResponse, error)
+	// Subscribe sends events until the client cancels the call.
+	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedRouteInfoServer()
+}
+
+// UnimplementedRouteInfoServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRouteInfoServer struct{}
+
+func (UnimplementedRouteInfoServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedRouteInfoServer) LookupBatch(context.Context, *LookupBatchRequest) (*LookupBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LookupBatch not implemented")
+}
+func (UnimplementedRouteInfoServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedRouteInfoServer) Neighbors(context.Context, *NeighborsRequest) (*NeighborsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Neighbors not implemented")
+}
+func (UnimplementedRouteInfoServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedRouteInfoServer) mustEmbedUnimplementedRouteInfoServer() {}
+func (UnimplementedRouteInfoServer) testEmbeddedByValue()                   {}
+
+// UnsafeRouteInfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RouteInfoServer will
+// result in compilation errors.
+type UnsafeRouteInfoServer interface {
+	mustEmbedUnimplementedRouteInfoServer()
+}
+
+func RegisterRouteInfoServer(s grpc.ServiceRegistrar, srv RouteInfoServer) {
+	// If the following call panics, it indicates UnimplementedRouteInfoServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RouteInfo_ServiceDesc, srv)
+}
+
+func _RouteInfo_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteInfoServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteInfo_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteInfoServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteInfo_LookupBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteInfoServer).LookupBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteInfo_LookupBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteInfoServer).LookupBatch(ctx, req.(*LookupBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteInfo_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteInfoServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteInfo_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteInfoServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteInfo_Neighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NeighborsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouteInfoServer).Neighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouteInfo_Neighbors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouteInfoServer).Neighbors(ctx, req.(*NeighborsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouteInfo_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouteInfoServer).Subscribe(m, &grpc.GenericServerStream[SubscribeRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouteInfo_SubscribeServer = grpc.ServerStreamingServer[Event]
+
+// RouteInfo_ServiceDesc is the grpc.ServiceDesc for RouteInfo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RouteInfo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "routeinfo.RouteInfo",
+	HandlerType: (*RouteInfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _RouteInfo_Lookup_Handler,
+		},
+		{
+			MethodName: "LookupBatch",
+			Handler:    _RouteInfo_LookupBatch_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _RouteInfo_Status_Handler,
+		},
+		{
+			MethodName: "Neighbors",
+			Handler:    _RouteInfo_Neighbors_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _RouteInfo_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "routeinfo.proto",
+}
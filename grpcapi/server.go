@@ -0,0 +1,122 @@
+package grpcapi
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// Server answers the gRPC requests using a RouteInfoServer.
+type Server struct {
+	UnimplementedRouteInfoServer
+	RouteInfo *routeinfo.RouteInfoServer
+}
+
+// NewServer returns a gRPC server with the RouteInfo service registered.
+func NewServer(rs *routeinfo.RouteInfoServer, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	RegisterRouteInfoServer(s, &Server{RouteInfo: rs})
+	return s
+}
+
+// routers returns the routers with the given names, or all routers if there
+// are none.
+func (s *Server) routers(names []string) (map[string]*routeinfo.Router, []string) {
+	if len(names) == 0 {
+		return s.RouteInfo.Routers, nil
+	}
+	var errors []string
+	routers := make(map[string]*routeinfo.Router)
+	for _, name := range names {
+		if router, ok := s.RouteInfo.Routers[name]; ok {
+			routers[name] = router
+		} else {
+			errors = append(errors, "Router not found.")
+		}
+	}
+	return routers, errors
+}
+
+func (s *Server) Lookup(ctx context.Context, request *LookupRequest) (*LookupResponse, error) {
+	routers, errors := s.routers(request.GetRouters())
+	return s.lookup(routers, errors, request.GetPrefix(), request.GetExplain()), nil
+}
+
+func (s *Server) lookup(routers map[string]*routeinfo.Router, errors []string, prefix string, explain bool) *LookupResponse {
+	response := &LookupResponse{Errors: errors}
+	for name, router := range routers {
+		paths := router.Lookup(prefix)
+		if len(paths) == 0 {
+			continue
+		}
+		result := &PrefixResult{Router: name, Prefix: paths[0].Prefix, Paths: NewPaths(paths)}
+		if explain {
+			for _, decision := range routeinfo.ExplainBestPath(paths) {
+				result.Explanation = append(result.Explanation, NewPathDecision(decision))
+			}
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response
+}
+
+func (s *Server) LookupBatch(ctx context.Context, request *LookupBatchRequest) (*LookupBatchResponse, error) {
+	routers, errors := s.routers(request.GetRouters())
+	response := &LookupBatchResponse{}
+	for _, prefix := range request.GetPrefixes() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		response.Responses = append(response.Responses, s.lookup(routers, errors, prefix, false))
+	}
+	return response, nil
+}
+
+func (s *Server) Status(ctx context.Context, request *StatusRequest) (*StatusResponse, error) {
+	response := &StatusResponse{}
+	for name, router := range s.RouteInfo.Routers {
+		response.Results = append(response.Results, &RouterStatus{Router: name, Ready: router.Established()})
+	}
+	return response, nil
+}
+
+func (s *Server) Neighbors(ctx context.Context, request *NeighborsRequest) (*NeighborsResponse, error) {
+	routers, errors := s.routers(request.GetRouters())
+	response := &NeighborsResponse{Errors: errors}
+	for name, router := range routers {
+		result := &RouterNeighbors{Router: name}
+		for _, neighbor := range router.NeighborStatus() {
+			result.Neighbors = append(result.Neighbors, NewNeighborStatus(neighbor))
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+// Subscribe sends events until the client cancels the call.
+func (s *Server) Subscribe(request *SubscribeRequest, stream grpc.ServerStreamingServer[Event]) error {
+	events, unsubscribe := s.RouteInfo.Subscribe(100)
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if len(request.GetRouters()) > 0 && !slices.Contains(request.GetRouters(), event.Router) {
+				continue
+			}
+			if len(request.GetTypes()) > 0 && !slices.Contains(request.GetTypes(), string(event.Type)) {
+				continue
+			}
+			if err := stream.Send(NewEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
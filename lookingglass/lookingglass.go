@@ -0,0 +1,98 @@
+// Package lookingglass embeds the looking glass web frontend, so it can be
+// served by routeinfo_server together with a config.js generated from the
+// server configuration.
+package lookingglass
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+// Files are the static files of the looking glass. config.js is not included,
+// it is generated by ConfigJS.
+//
+//go:embed lookingglass.html lookingglass.js style.css
+var Files embed.FS
+
+// Config are the settings of the looking glass, corresponding to the
+// "routeinfoAPI" and "graph" sections of config.js.
+type Config struct {
+	URL             string `yaml:"url"`             // URL of the API without trailing slash, defaults to the server of the looking glass
+	ShowAPILink     bool   `yaml:"showapilink"`     // show a link to the raw JSON result
+	Graph           bool   `yaml:"graph"`           // render a BGP tree, requires mermaid.js
+	LocalASName     string `yaml:"localasname"`     // display name of our own AS in the graph
+	DrawLocalASLoop bool   `yaml:"drawlocalasloop"` // draw a loop from our own AS to itself for local paths
+	DrawPrepends    bool   `yaml:"drawprepends"`    // draw loops for prepends
+}
+
+// DefaultConfig returns the settings of the example config.js.
+func DefaultConfig() Config {
+	return Config{
+		ShowAPILink:     true,
+		Graph:           true,
+		DrawLocalASLoop: true,
+	}
+}
+
+// tags are always known to the looking glass, they are not communities but
+// shown the same way.
+var tags = map[string]routeinfo.CommunityInfo{
+	"best":          {Description: "router-local best-path", Class: "best"},
+	"RPKI NotFound": {Description: "RPKI origin validation status \"NotFound\"", Class: "rpki-notfound"},
+	"RPKI Valid":    {Description: "RPKI origin validation status \"Valid\"", Class: "rpki-valid"},
+	"RPKI Invalid":  {Description: "RPKI origin validation status \"Invalid\"", Class: "rpki-invalid"},
+}
+
+// ConfigJS returns a config.js with the settings and a tag for each of the
+// communities, i.e. those of RouteInfoServer.Communities.Entries(). Patterns
+// are left out, as the looking glass only shows tags of exact matches.
+func (c Config) ConfigJS(communities map[string]routeinfo.CommunityInfo) ([]byte, error) {
+	all := make(map[string]routeinfo.CommunityInfo)
+	for community, info := range communities {
+		if !strings.ContainsAny(community, "x*") {
+			all[community] = info
+		}
+	}
+	maps.Copy(all, tags)
+	settings := map[string]any{
+		"routeinfoAPI": map[string]any{
+			"URL":         c.URL,
+			"showAPILink": c.ShowAPILink,
+		},
+		"graph": map[string]any{
+			"enabled":         c.Graph,
+			"localASName":     c.LocalASName,
+			"drawLocalAsLoop": c.DrawLocalASLoop,
+			"drawPrepends":    c.DrawPrepends,
+		},
+		"tags": all,
+	}
+	content, err := json.MarshalIndent(settings, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("generating config.js: %w", err)
+	}
+	return fmt.Appendf(nil, "lgSettings = %s;\n", content), nil
+}
+
+// Handler serves the looking glass at / of the handler, with the given
+// config.js.
+func Handler(configJS []byte) http.Handler {
+	files := http.FileServerFS(Files)
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/", "/lookingglass.html":
+			http.ServeFileFS(writer, request, Files, "lookingglass.html")
+		case "/config.js":
+			writer.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+			writer.Write(configJS)
+		default:
+			files.ServeHTTP(writer, request)
+		}
+	})
+}
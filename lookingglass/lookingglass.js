@@ -58,12 +58,16 @@ function addTextElement(tag, text, container, classes = []) {
     container.appendChild(element);
 }
 
-function newTag(text) {
+function newTag(text, annotation = null) {
     var tagTemplate = document.querySelector("#lg-template-tag");
     var tag = document.importNode(tagTemplate.content, true);
     var tagElement = tag.querySelector("#lg-tag");
     tagElement.textContent = text;
     var tagInfo = lgSettings.tags[text];
+    if (!tagInfo && annotation && annotation.description) {
+        // fall back to the server-side community dictionary
+        tagInfo = annotation;
+    }
     if (tagInfo) {
         tagElement.classList.add(`lg-tag-class-${tagInfo.class}`);
         tagElement.classList.add("lg-tag-tooltip");
@@ -82,7 +86,23 @@ function addPathElement(path, container) {
 
     // path data (as-path, next-hop, timestamp, metrics)
     if ("aspath" in path && path.aspath) {
-        pathElement.querySelector("#lg-path-aspath").textContent = path.aspath.join(" ");
+        var aspathElement = pathElement.querySelector("#lg-path-aspath");
+        path.aspath.forEach(function(asn, i){
+            if (i > 0) {
+                aspathElement.appendChild(document.createTextNode(" "));
+            }
+            var asnElement = document.createElement("span");
+            asnElement.textContent = asn;
+            // names are only returned if the server has them loaded
+            if (path.aspath_names && path.aspath_names[i]) {
+                var name = path.aspath_names[i];
+                var title = [name.name, name.organization, name.country].filter(Boolean).join(", ");
+                if (title) {
+                    asnElement.title = title;
+                }
+            }
+            aspathElement.appendChild(asnElement);
+        });
     } else {
         pathElement.querySelector("#lg-path-aspath").textContent = "(local)";
     }
@@ -99,14 +119,20 @@ function addPathElement(path, container) {
 
     // communities and large-communities
     var tagSection = pathElement.querySelector("#lg-path-tags");
+    var annotations = {};
+    if (path.annotatedcommunities) {
+        path.annotatedcommunities.forEach(function(annotation){
+            annotations[annotation.community] = annotation;
+        });
+    }
     if (path.communities) {
         path.communities.forEach(function(community){
-            tagSection.appendChild(newTag(community));
+            tagSection.appendChild(newTag(community, annotations[community]));
         });
     }
     if (path.largecommunities) {
         path.largecommunities.forEach(function(community){
-            tagSection.appendChild(newTag(community));
+            tagSection.appendChild(newTag(community, annotations[community]));
         });
     }
 
@@ -0,0 +1,81 @@
+package lookingglass
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/BelWue/bgp_routeinfo/routeinfo"
+)
+
+func TestConfigJS(t *testing.T) {
+	config := DefaultConfig()
+	config.LocalASName = "Example Net"
+	js, err := config.ConfigJS(map[string]routeinfo.CommunityInfo{
+		"64500:666":  {Description: "blackhole", Class: "action-blackhole"},
+		"64500:1xxx": {Description: "learned from customer", Class: "info-customer"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, ok := bytes.CutPrefix(js, []byte("lgSettings = "))
+	if !ok {
+		t.Fatalf("config.js does not set lgSettings: %s", js)
+	}
+	var settings struct {
+		RouteinfoAPI struct {
+			URL         string
+			ShowAPILink bool
+		}
+		Graph struct {
+			Enabled     bool
+			LocalASName string
+		}
+		Tags map[string]routeinfo.CommunityInfo
+	}
+	if err := json.Unmarshal(bytes.TrimSuffix(content, []byte(";\n")), &settings); err != nil {
+		t.Fatal(err)
+	}
+	if settings.RouteinfoAPI.URL != "" || !settings.RouteinfoAPI.ShowAPILink {
+		t.Errorf("unexpected API settings %+v", settings.RouteinfoAPI)
+	}
+	if !settings.Graph.Enabled || settings.Graph.LocalASName != "Example Net" {
+		t.Errorf("unexpected graph settings %+v", settings.Graph)
+	}
+	if settings.Tags["64500:666"].Class != "action-blackhole" {
+		t.Errorf("community missing from tags: %+v", settings.Tags)
+	}
+	if _, ok := settings.Tags["64500:1xxx"]; ok {
+		t.Errorf("pattern included in tags: %+v", settings.Tags)
+	}
+	if settings.Tags["best"].Class != "best" || settings.Tags["RPKI Invalid"].Class != "rpki-invalid" {
+		t.Errorf("built-in tags missing: %+v", settings.Tags)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler([]byte("lgSettings = {};\n"))
+	for _, tc := range []struct {
+		path     string
+		status   int
+		contains string
+	}{
+		{"/", http.StatusOK, "<template id=\"lg-template-query\">"},
+		{"/config.js", http.StatusOK, "lgSettings = {};"},
+		{"/lookingglass.js", http.StatusOK, "lgSettings.routeinfoAPI.URL"},
+		{"/style.css", http.StatusOK, ""},
+		{"/missing.js", http.StatusNotFound, ""},
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if recorder.Code != tc.status {
+			t.Errorf("%s: got status %d, expected %d", tc.path, recorder.Code, tc.status)
+		}
+		if !strings.Contains(recorder.Body.String(), tc.contains) {
+			t.Errorf("%s: body does not contain %q", tc.path, tc.contains)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package routeinfo
+
+import (
+	"slices"
+)
+
+type ASPAState uint8
+
+const (
+	ASPAValid ASPAState = iota
+	ASPAUnknown
+	ASPAInvalid
+)
+
+func (s ASPAState) String() string {
+	switch s {
+	case ASPAValid:
+		return "Valid"
+	case ASPAUnknown:
+		return "Unknown"
+	case ASPAInvalid:
+		return "Invalid"
+	default:
+		return "Unknown"
+	}
+}
+
+// ASPAHop is a pair of adjacent ASes in an AS path, Customer being the one
+// closer to the origin.
+type ASPAHop struct {
+	Customer uint32 `json:"customer"`
+	Provider uint32 `json:"provider"`
+}
+
+// ASPAResult is the outcome of the AS path verification described in
+// draft-ietf-sidrops-aspa-verification. For invalid paths, Hop is the first
+// hop at which the path could not have been propagated validly.
+type ASPAResult struct {
+	State      ASPAState `json:"state"`
+	Downstream bool      `json:"downstream"`
+	Hop        *ASPAHop  `json:"hop,omitempty"`
+}
+
+type aspaHopResult uint8
+
+const (
+	aspaProvider aspaHopResult = iota
+	aspaNotProvider
+	aspaNoAttestation
+)
+
+// SetASPAs replaces the current ASPA objects, a map of customer ASN to the
+// list of its provider ASNs.
+func (v *RPKIValidator) SetASPAs(aspas map[uint32][]uint32) {
+	index := make(map[uint32][]uint32, len(aspas))
+	for customer, providers := range aspas {
+		sorted := slices.Clone(providers)
+		slices.Sort(sorted)
+		index[customer] = sorted
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.aspas = index
+}
+
+// ASPACount returns the number of ASPA objects currently known.
+func (v *RPKIValidator) ASPACount() int {
+	if v == nil {
+		return 0
+	}
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return len(v.aspas)
+}
+
+// IsProvider reports whether the customer AS has an ASPA object listing the
+// provider AS.
+func (v *RPKIValidator) IsProvider(customer uint32, provider uint32) bool {
+	return v.hop(customer, provider) == aspaProvider
+}
+
+func (v *RPKIValidator) hop(customer uint32, provider uint32) aspaHopResult {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	providers, ok := v.aspas[customer]
+	if !ok {
+		return aspaNoAttestation
+	}
+	if _, found := slices.BinarySearch(providers, provider); found {
+		return aspaProvider
+	}
+	return aspaNotProvider
+}
+
+// VerifyASPA verifies an AS path as received, i.e. with the neighbor AS first
+// and the origin AS last. Paths received from customers, peers and route
+// servers are checked using the upstream algorithm, paths received from a
+// provider using the downstream algorithm.
+func (v *RPKIValidator) VerifyASPA(aspath []uint32, downstream bool) ASPAResult {
+	// reverse and remove prepends, path[0] is the origin now
+	var path []uint32
+	for i := len(aspath) - 1; i >= 0; i-- {
+		if len(path) == 0 || path[len(path)-1] != aspath[i] {
+			path = append(path, aspath[i])
+		}
+	}
+	result := ASPAResult{State: ASPAValid, Downstream: downstream}
+	n := len(path)
+	if n <= 1 || (downstream && n <= 2) {
+		return result
+	}
+
+	// The up-ramp leads from the origin to the top of the path, each AS being
+	// a customer of the next one. max counts hops without explicit
+	// contradiction, min only hops that are attested.
+	maxUp, minUp := n, n
+	for i := 0; i < n-1; i++ {
+		h := v.hop(path[i], path[i+1])
+		if h != aspaProvider && minUp == n {
+			minUp = i + 1
+		}
+		if h == aspaNotProvider {
+			maxUp = i + 1
+			break
+		}
+	}
+
+	if !downstream {
+		if maxUp < n {
+			result.State = ASPAInvalid
+			result.Hop = &ASPAHop{Customer: path[maxUp-1], Provider: path[maxUp]}
+		} else if minUp < n {
+			result.State = ASPAUnknown
+		}
+		return result
+	}
+
+	// the down-ramp leads from us to the top of the path
+	maxDown, minDown := n, n
+	for j := n - 1; j > 0; j-- {
+		h := v.hop(path[j], path[j-1])
+		if h != aspaProvider && minDown == n {
+			minDown = n - j
+		}
+		if h == aspaNotProvider {
+			maxDown = n - j
+			break
+		}
+	}
+	if maxUp+maxDown < n {
+		result.State = ASPAInvalid
+		result.Hop = &ASPAHop{Customer: path[maxUp-1], Provider: path[maxUp]}
+	} else if minUp+minDown < n {
+		result.State = ASPAUnknown
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package routeinfo
+
+import "testing"
+
+func TestVerifyASPA(t *testing.T) {
+	var v RPKIValidator
+	// 64500 and 64501 are customers of 64510, which is a customer of 64520.
+	// 64530 is a peer of 64520 and has an ASPA listing 64540 only.
+	v.SetASPAs(map[uint32][]uint32{
+		64500: {64510},
+		64501: {64510},
+		64510: {64520},
+		64520: {},
+		64530: {64540},
+	})
+
+	tests := []struct {
+		name       string
+		aspath     []uint32
+		downstream bool
+		state      ASPAState
+		hop        *ASPAHop
+	}{
+		{"origin only", []uint32{64500}, false, ASPAValid, nil},
+		{"customer chain", []uint32{64520, 64510, 64510, 64500}, false, ASPAValid, nil},
+		{"unattested origin", []uint32{64510, 64599}, false, ASPAUnknown, nil},
+		{"leak via customer", []uint32{64501, 64510, 64500}, false, ASPAInvalid, &ASPAHop{Customer: 64510, Provider: 64501}},
+		{"leak to peer", []uint32{64530, 64520, 64510, 64500}, false, ASPAInvalid, &ASPAHop{Customer: 64520, Provider: 64530}},
+		{"valley free from provider", []uint32{64510, 64520, 64510, 64501}, true, ASPAValid, nil},
+		{"down ramp unattested", []uint32{64599, 64598, 64520, 64510, 64500}, true, ASPAUnknown, nil},
+		{"valley from provider", []uint32{64510, 64500, 64510, 64501}, true, ASPAInvalid, &ASPAHop{Customer: 64510, Provider: 64500}},
+	}
+	for _, test := range tests {
+		result := v.VerifyASPA(test.aspath, test.downstream)
+		if result.State != test.state {
+			t.Errorf("%s: got state %s, want %s", test.name, result.State, test.state)
+		}
+		if (result.Hop == nil) != (test.hop == nil) || (result.Hop != nil && *result.Hop != *test.hop) {
+			t.Errorf("%s: got hop %+v, want %+v", test.name, result.Hop, test.hop)
+		}
+	}
+}
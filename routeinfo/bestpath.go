@@ -0,0 +1,116 @@
+package routeinfo
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// DecisionStep is a step of the BGP best path selection.
+type DecisionStep string
+
+const (
+	StepLocalPref    DecisionStep = "local-pref"
+	StepASPathLength DecisionStep = "as-path-length"
+	StepOrigin       DecisionStep = "origin"
+	StepMED          DecisionStep = "med"
+	StepExternal     DecisionStep = "ebgp-over-ibgp"
+	StepRouterId     DecisionStep = "router-id"
+	StepClusterList  DecisionStep = "cluster-list-length"
+	// none of the steps we can evaluate decided, so the path lost at the IGP
+	// cost, its age, the router ID or address of the neighbor, or it is an
+	// equal cost multipath
+	StepUndetermined DecisionStep = "undetermined"
+)
+
+// PathDecision explains at which step of the best path selection a path lost
+// against the best path.
+type PathDecision struct {
+	Path   RouteInfo    `json:"path"`
+	LostAt DecisionStep `json:"lostat"`
+	Reason string       `json:"reason"`
+}
+
+// ExplainBestPath looks up a prefix and explains for each path that is not
+// the best path why it was not selected.
+func (r *Router) ExplainBestPath(prefix string) []PathDecision {
+	return ExplainBestPath(r.Lookup(prefix))
+}
+
+// ExplainBestPath compares each path of a prefix that is not marked best with
+// the best path, following RFC 4271 section 9.1.2.2 and RFC 4456. The IGP cost
+// to the next hop, when the router learned a path and the router ID and
+// address of the neighbor it learned it from are not known to us, so paths
+// that are equally good up to these steps lost at StepUndetermined.
+func ExplainBestPath(paths []RouteInfo) []PathDecision {
+	var best *RouteInfo
+	for i := range paths {
+		if paths[i].Best {
+			best = &paths[i]
+			break
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	var decisions []PathDecision
+	for _, path := range paths {
+		if path.Best {
+			continue
+		}
+		step, reason := comparePaths(best, &path)
+		decisions = append(decisions, PathDecision{Path: path, LostAt: step, Reason: reason})
+	}
+	return decisions
+}
+
+// isExternal guesses whether the router learned a path via eBGP. All paths are
+// received from the router via iBGP, but paths it learned via iBGP itself
+// carry an originator ID when reflected to us.
+func (path *RouteInfo) isExternal() bool {
+	return path.OriginatorId == "" && len(path.AsPath) > 0
+}
+
+func comparePaths(best *RouteInfo, path *RouteInfo) (DecisionStep, string) {
+	if best.LocalPref != path.LocalPref {
+		return StepLocalPref, fmt.Sprintf("local-pref %d is lower than %d", path.LocalPref, best.LocalPref)
+	}
+	if len(best.AsPath) != len(path.AsPath) {
+		return StepASPathLength, fmt.Sprintf("AS path length %d is longer than %d", len(path.AsPath), len(best.AsPath))
+	}
+	if best.Origin != path.Origin {
+		return StepOrigin, fmt.Sprintf("origin %s is worse than %s", path.Origin, best.Origin)
+	}
+	// MEDs are only comparable between paths from the same neighbor AS
+	if best.Med != path.Med && (len(best.AsPath) == 0 && len(path.AsPath) == 0 ||
+		len(best.AsPath) > 0 && len(path.AsPath) > 0 && best.AsPath[0] == path.AsPath[0]) {
+		return StepMED, fmt.Sprintf("MED %d is higher than %d", path.Med, best.Med)
+	}
+	if best.isExternal() && !path.isExternal() {
+		return StepExternal, "path was learned via iBGP, best path via eBGP"
+	}
+	// Our session to the router is the same for all paths, so its router ID
+	// and address tell nothing about the neighbors, and the timestamp is when
+	// we received a path. Only reflected paths carry the router ID of their
+	// originator, which decides if the IGP cost is the same, i.e. for the same
+	// next hop.
+	if best.OriginatorId == "" || path.OriginatorId == "" || best.NextHop != path.NextHop {
+		return StepUndetermined, "paths are equally good up to the IGP cost, age or neighbor, which are not known"
+	}
+	if compareAddrStrings(best.OriginatorId, path.OriginatorId) != 0 {
+		return StepRouterId, fmt.Sprintf("originator ID %s is higher than %s", path.OriginatorId, best.OriginatorId)
+	}
+	if len(best.ClusterList) != len(path.ClusterList) {
+		return StepClusterList, fmt.Sprintf("cluster list length %d is longer than %d", len(path.ClusterList), len(best.ClusterList))
+	}
+	return StepUndetermined, "paths are equally good up to the address of the neighbor, which is not known"
+}
+
+func compareAddrStrings(a string, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return addrA.Compare(addrB)
+}
@@ -0,0 +1,76 @@
+package routeinfo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExplainBestPath(t *testing.T) {
+	// all paths are received on our session to the router, so they share its
+	// address and router ID, and their timestamp is when we received them
+	now := time.Now()
+	best := RouteInfo{
+		Best:      true,
+		AsPath:    []uint32{64500, 64501},
+		LocalPref: 200,
+		Med:       10,
+		NextHop:   "198.51.100.1",
+		Peer:      "192.0.2.254",
+		RouterId:  "10.0.0.254",
+		Timestamp: now.Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *RouteInfo)
+		step   DecisionStep
+	}{
+		{"local-pref", func(p *RouteInfo) { p.LocalPref = 100 }, StepLocalPref},
+		{"as-path", func(p *RouteInfo) { p.AsPath = []uint32{64502, 64503, 64501} }, StepASPathLength},
+		{"origin", func(p *RouteInfo) { p.Origin = Incomplete }, StepOrigin},
+		{"med same neighbor", func(p *RouteInfo) { p.Med = 20 }, StepMED},
+		{"med other neighbor", func(p *RouteInfo) { p.Med = 20; p.AsPath = []uint32{64502, 64501} }, StepUndetermined},
+		{"ibgp", func(p *RouteInfo) { p.OriginatorId = "10.0.0.2" }, StepExternal},
+		{"ebgp newer", func(p *RouteInfo) { p.NextHop = "198.51.100.2"; p.Timestamp = now }, StepUndetermined},
+		{"ebgp older", func(p *RouteInfo) { p.NextHop = "198.51.100.2"; p.Timestamp = now.Add(-2 * time.Hour) }, StepUndetermined},
+	}
+	for _, test := range tests {
+		path := best
+		path.Best = false
+		test.modify(&path)
+		decisions := ExplainBestPath([]RouteInfo{path, best})
+		if len(decisions) != 1 || decisions[0].LostAt != test.step {
+			t.Errorf("%s: got %+v, want %s", test.name, decisions, test.step)
+		}
+	}
+
+	// both paths reflected, compare originator ID and cluster list
+	bestReflected := best
+	bestReflected.AsPath = []uint32{64502, 64501}
+	bestReflected.OriginatorId = "10.0.0.1"
+	bestReflected.ClusterList = []string{"10.0.0.10"}
+	reflected := bestReflected
+	reflected.Best = false
+	reflected.OriginatorId = "10.0.0.2"
+	decisions := ExplainBestPath([]RouteInfo{bestReflected, reflected})
+	if len(decisions) != 1 || decisions[0].LostAt != StepRouterId {
+		t.Errorf("router ID: got %+v", decisions)
+	}
+	longer := bestReflected
+	longer.Best = false
+	longer.ClusterList = []string{"10.0.0.10", "10.0.0.11"}
+	decisions = ExplainBestPath([]RouteInfo{bestReflected, longer})
+	if len(decisions) != 1 || decisions[0].LostAt != StepClusterList {
+		t.Errorf("cluster list: got %+v", decisions)
+	}
+	// with another next hop, the IGP cost may have decided first
+	reflected.NextHop = "198.51.100.2"
+	decisions = ExplainBestPath([]RouteInfo{bestReflected, reflected})
+	if len(decisions) != 1 || decisions[0].LostAt != StepUndetermined {
+		t.Errorf("IGP cost: got %+v", decisions)
+	}
+
+	if decisions := ExplainBestPath([]RouteInfo{reflected}); decisions != nil {
+		t.Errorf("explained paths without best path: %+v", decisions)
+	}
+}
@@ -0,0 +1,303 @@
+package routeinfo
+
+import (
+	"bufio"
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BogonKind string
+
+const (
+	BogonSpecialPurpose BogonKind = "special-purpose"
+	BogonUnallocated    BogonKind = "unallocated"
+	BogonASN            BogonKind = "asn"
+)
+
+// BogonReason explains why a path is a bogon, Match being the special-purpose
+// or unallocated prefix covering it or the bogon AS in its AS path.
+type BogonReason struct {
+	Kind        BogonKind `json:"kind"`
+	Match       string    `json:"match"`
+	Description string    `json:"description"`
+}
+
+// specialPurposePrefixes are the special-purpose address blocks of RFC 6890
+// and its updates which are not globally reachable, and other prefixes that
+// should never be seen in the global routing table. IPv6 prefixes outside of
+// globalUnicast are bogons as well.
+var specialPurposePrefixes = map[netip.Prefix]string{
+	netip.MustParsePrefix("0.0.0.0/8"):       "this network, RFC 791",
+	netip.MustParsePrefix("10.0.0.0/8"):      "private use, RFC 1918",
+	netip.MustParsePrefix("100.64.0.0/10"):   "shared address space, RFC 6598",
+	netip.MustParsePrefix("127.0.0.0/8"):     "loopback, RFC 1122",
+	netip.MustParsePrefix("169.254.0.0/16"):  "link local, RFC 3927",
+	netip.MustParsePrefix("172.16.0.0/12"):   "private use, RFC 1918",
+	netip.MustParsePrefix("192.0.0.0/24"):    "IETF protocol assignments, RFC 6890",
+	netip.MustParsePrefix("192.0.2.0/24"):    "documentation, RFC 5737",
+	netip.MustParsePrefix("192.88.99.0/24"):  "6to4 relay anycast, RFC 7526",
+	netip.MustParsePrefix("192.168.0.0/16"):  "private use, RFC 1918",
+	netip.MustParsePrefix("198.18.0.0/15"):   "benchmarking, RFC 2544",
+	netip.MustParsePrefix("198.51.100.0/24"): "documentation, RFC 5737",
+	netip.MustParsePrefix("203.0.113.0/24"):  "documentation, RFC 5737",
+	netip.MustParsePrefix("224.0.0.0/4"):     "multicast, RFC 5771",
+	netip.MustParsePrefix("240.0.0.0/4"):     "reserved, RFC 1112",
+	netip.MustParsePrefix("::/8"):            "reserved by IETF, RFC 4291",
+	netip.MustParsePrefix("100::/64"):        "discard only, RFC 6666",
+	netip.MustParsePrefix("2001:2::/48"):     "benchmarking, RFC 5180",
+	netip.MustParsePrefix("2001:10::/28"):    "ORCHID, RFC 4843",
+	netip.MustParsePrefix("2001:db8::/32"):   "documentation, RFC 3849",
+	netip.MustParsePrefix("2002::/16"):       "6to4, RFC 7526",
+	netip.MustParsePrefix("3ffe::/16"):       "6bone, RFC 3701",
+	netip.MustParsePrefix("3fff::/20"):       "documentation, RFC 9637",
+	netip.MustParsePrefix("5f00::/16"):       "SRv6 SIDs, RFC 9602",
+	netip.MustParsePrefix("fc00::/7"):        "unique local, RFC 4193",
+	netip.MustParsePrefix("fe80::/10"):       "link local, RFC 4291",
+	netip.MustParsePrefix("fec0::/10"):       "site local, RFC 3879",
+	netip.MustParsePrefix("ff00::/8"):        "multicast, RFC 4291",
+}
+
+var globalUnicast = netip.MustParsePrefix("2000::/3")
+
+// bogonASN returns why an AS must not appear in the global routing table.
+func bogonASN(asn uint32) string {
+	switch {
+	case asn == 0:
+		return "reserved, RFC 7607"
+	case asn == 23456:
+		return "AS_TRANS, RFC 6793"
+	case asn >= 64496 && asn <= 64511, asn >= 65536 && asn <= 65551:
+		return "documentation, RFC 5398"
+	case asn >= 64512 && asn <= 65534, asn >= 4200000000 && asn <= 4294967294:
+		return "private use, RFC 6996"
+	case asn == 65535, asn == 4294967295:
+		return "reserved, RFC 7300"
+	case asn >= 65552 && asn <= 131071:
+		return "reserved by IANA"
+	}
+	return ""
+}
+
+// bogonPathsReceived counts the bogon paths received from the routers, which
+// should be zero if their import filters work.
+var bogonPathsReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "routeinfo_bogon_paths_received_total",
+	Help: "Number of paths for bogon prefixes or with bogon ASNs received from the routers.",
+}, []string{"router", "kind"})
+
+// Collectors returns the Prometheus metrics of this package.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{bogonPathsReceived}
+}
+
+// BogonDetector flags paths for special-purpose or unallocated prefixes and
+// paths with reserved or private ASNs. Unallocated space is loaded from files
+// with one prefix per line, i.e. the Team Cymru fullbogons lists.
+type BogonDetector struct {
+	Files   []string `yaml:"files"`   // unallocated prefixes, lines starting with # are ignored
+	Refresh int      `yaml:"refresh"` // seconds between reloads of the files, defaults to 86400
+
+	Logger log.RouteinfoLogger
+
+	lock        sync.RWMutex
+	unallocated map[netip.Prefix]struct{}
+	stop        chan struct{}
+}
+
+// Start loads the configured files and reloads them in the background until
+// Stop is called.
+func (b *BogonDetector) Start() error {
+	b.stop = make(chan struct{})
+	if len(b.Files) == 0 {
+		return nil
+	}
+	if err := b.LoadFiles(b.Files...); err != nil {
+		return err
+	}
+	refresh := time.Duration(b.Refresh) * time.Second
+	if refresh <= 0 {
+		refresh = 86400 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-b.stop:
+				return
+			case <-ticker.C:
+				if err := b.LoadFiles(b.Files...); err != nil {
+					b.logger().Errorf("Failed to reload bogon files: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (b *BogonDetector) Stop() {
+	if b.stop != nil {
+		close(b.stop)
+		b.stop = nil
+	}
+}
+
+// LoadFiles replaces the current unallocated prefixes with those from the
+// files.
+func (b *BogonDetector) LoadFiles(filenames ...string) error {
+	var prefixes []netip.Prefix
+	for _, filename := range filenames {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(file)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(text)
+			if err != nil {
+				file.Close()
+				return fmt.Errorf("parsing bogon file %s line %d: %w", filename, line, err)
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	b.SetUnallocated(prefixes)
+	b.logger().Infof("Loaded %d unallocated prefixes from %s", len(prefixes), strings.Join(filenames, ", "))
+	return nil
+}
+
+// SetUnallocated replaces the current unallocated prefixes.
+func (b *BogonDetector) SetUnallocated(prefixes []netip.Prefix) {
+	unallocated := make(map[netip.Prefix]struct{}, len(prefixes))
+	for _, prefix := range prefixes {
+		unallocated[prefix.Masked()] = struct{}{}
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.unallocated = unallocated
+}
+
+// Check returns why a path for the prefix with the AS path is a bogon, if it
+// is one.
+func (b *BogonDetector) Check(prefix netip.Prefix, aspath []uint32) []BogonReason {
+	if b == nil {
+		return nil
+	}
+	var reasons []BogonReason
+	b.lock.RLock()
+	for bits := 0; bits <= prefix.Bits(); bits++ {
+		p, err := prefix.Addr().Prefix(bits)
+		if err != nil {
+			break
+		}
+		if description, ok := specialPurposePrefixes[p]; ok {
+			reasons = append(reasons, BogonReason{Kind: BogonSpecialPurpose, Match: p.String(), Description: description})
+		}
+		if _, ok := b.unallocated[p]; ok {
+			reasons = append(reasons, BogonReason{Kind: BogonUnallocated, Match: p.String(), Description: "unallocated"})
+		}
+	}
+	b.lock.RUnlock()
+	if len(reasons) == 0 && prefix.Addr().Is6() && prefix.Bits() >= 3 && !globalUnicast.Contains(prefix.Addr()) {
+		p, _ := prefix.Addr().Prefix(3)
+		reasons = append(reasons, BogonReason{Kind: BogonSpecialPurpose, Match: p.String(), Description: "outside of global unicast, RFC 4291"})
+	}
+	seen := make(map[uint32]bool)
+	for _, asn := range aspath {
+		if description := bogonASN(asn); description != "" && !seen[asn] {
+			seen[asn] = true
+			reasons = append(reasons, BogonReason{Kind: BogonASN, Match: fmt.Sprintf("AS%d", asn), Description: description})
+		}
+	}
+	return reasons
+}
+
+// countBogons updates the metrics for a path received in an update.
+func (r *Router) countBogons(path *apiutil.Path) {
+	if r.bogons == nil || path.Nlri == nil || path.Withdrawal {
+		return
+	}
+	prefix, err := netip.ParsePrefix(path.Nlri.String())
+	if err != nil {
+		return
+	}
+	var aspath []uint32
+	for _, a := range path.Attrs {
+		if attr, ok := a.(*bgp.PathAttributeAsPath); ok {
+			for _, segment := range attr.Value {
+				aspath = append(aspath, segment.GetAS()...)
+			}
+		}
+	}
+	kinds := make(map[BogonKind]bool)
+	for _, reason := range r.bogons.Check(prefix, aspath) {
+		if !kinds[reason.Kind] {
+			kinds[reason.Kind] = true
+			bogonPathsReceived.WithLabelValues(r.Name, string(reason.Kind)).Inc()
+		}
+	}
+}
+
+func (b *BogonDetector) logger() log.ApplicationLogger {
+	if b.Logger == nil {
+		b.Logger = &log.DefaultRouteInfoLogger{}
+	}
+	return b.Logger.GetApplicationLogger()
+}
+
+// BogonReport are all bogon paths in the RIB of a router.
+type BogonReport struct {
+	IPv4  int               `json:"ipv4"`
+	IPv6  int               `json:"ipv6"`
+	Kinds map[BogonKind]int `json:"kinds"`
+	Paths []RouteInfo       `json:"paths"`
+}
+
+// Bogons returns all paths flagged as bogon, with the number of affected
+// prefixes per family and the number of paths per kind of bogon.
+func (r *Router) Bogons() BogonReport {
+	report := BogonReport{Kinds: make(map[BogonKind]int)}
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		found := false
+		for _, path := range paths {
+			if len(path.Bogon) == 0 {
+				continue
+			}
+			found = true
+			report.Paths = append(report.Paths, path)
+			kinds := make(map[BogonKind]bool)
+			for _, reason := range path.Bogon {
+				if !kinds[reason.Kind] {
+					kinds[reason.Kind] = true
+					report.Kinds[reason.Kind]++
+				}
+			}
+		}
+		if found {
+			if isIPv6Prefix(prefix) {
+				report.IPv6++
+			} else {
+				report.IPv4++
+			}
+		}
+	})
+	return report
+}
@@ -0,0 +1,106 @@
+package routeinfo
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBogonDetector(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fullbogons-ipv4.txt")
+	if err := os.WriteFile(file, []byte("# last updated today\n\n0.0.0.0/8\n5.0.0.0/16\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	detector := &BogonDetector{}
+	if err := detector.LoadFiles(file); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		prefix string
+		aspath []uint32
+		want   []BogonReason
+	}{
+		{"193.196.0.0/15", []uint32{3320, 553}, nil},
+		{"0.0.0.0/0", []uint32{3320}, nil},
+		{"10.1.0.0/16", []uint32{3320}, []BogonReason{{BogonSpecialPurpose, "10.0.0.0/8", "private use, RFC 1918"}}},
+		{"5.0.1.0/24", []uint32{3320}, []BogonReason{{BogonUnallocated, "5.0.0.0/16", "unallocated"}}},
+		{"193.196.0.0/15", []uint32{3320, 64512, 23456, 64512}, []BogonReason{
+			{BogonASN, "AS64512", "private use, RFC 6996"},
+			{BogonASN, "AS23456", "AS_TRANS, RFC 6793"},
+		}},
+		{"2001:7c0::/32", []uint32{3320, 553}, nil},
+		{"2001:db8:1::/48", []uint32{3320}, []BogonReason{{BogonSpecialPurpose, "2001:db8::/32", "documentation, RFC 3849"}}},
+		{"fd00::/8", []uint32{3320}, []BogonReason{{BogonSpecialPurpose, "fc00::/7", "unique local, RFC 4193"}}},
+		{"4001::/16", []uint32{3320}, []BogonReason{{BogonSpecialPurpose, "4000::/3", "outside of global unicast, RFC 4291"}}},
+		{"::/0", []uint32{3320}, nil},
+		{"193.196.0.0/15", []uint32{4200000000}, []BogonReason{{BogonASN, "AS4200000000", "private use, RFC 6996"}}},
+		{"193.196.0.0/15", []uint32{70000}, []BogonReason{{BogonASN, "AS70000", "reserved by IANA"}}},
+	}
+	for _, test := range tests {
+		got := detector.Check(netip.MustParsePrefix(test.prefix), test.aspath)
+		if len(got) != len(test.want) {
+			t.Errorf("%s %v: got %+v, want %+v", test.prefix, test.aspath, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s %v: got %+v, want %+v", test.prefix, test.aspath, got, test.want)
+				break
+			}
+		}
+	}
+
+	if err := os.WriteFile(file, []byte("5.0.0.0/33\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := detector.LoadFiles(file); err == nil {
+		t.Error("invalid bogon file loaded")
+	}
+}
+
+func TestBogons(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "10.0.0.0/8", nexthop: "198.51.100.1", aspath: []uint32{3320, 64512}, localPref: 100},
+		testRoute{prefix: "193.196.0.0/15", nexthop: "198.51.100.1", aspath: []uint32{3320, 553}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:7c0::1", aspath: []uint32{3320, 553}, localPref: 100},
+	)
+	router.bogons = &BogonDetector{}
+
+	report := router.Bogons()
+	if report.IPv4 != 1 || report.IPv6 != 1 || len(report.Paths) != 2 {
+		t.Fatalf("got %d IPv4 and %d IPv6 prefixes with %d paths", report.IPv4, report.IPv6, len(report.Paths))
+	}
+	if report.Kinds[BogonSpecialPurpose] != 2 || report.Kinds[BogonASN] != 1 {
+		t.Errorf("unexpected kinds %v", report.Kinds)
+	}
+
+	before := testutil.ToFloat64(bogonPathsReceived.WithLabelValues(router.Name, string(BogonASN)))
+	router.countBogons(newTestPath(t, testRoute{prefix: "193.196.0.0/15", nexthop: "198.51.100.1", aspath: []uint32{64512, 64513}}))
+	router.countBogons(newTestPath(t, testRoute{prefix: "193.196.0.0/15", nexthop: "198.51.100.1", aspath: []uint32{3320, 553}}))
+	if after := testutil.ToFloat64(bogonPathsReceived.WithLabelValues(router.Name, string(BogonASN))); after != before+1 {
+		t.Errorf("bogon ASN counter went from %f to %f", before, after)
+	}
+}
+
+func TestBogonMetricRouterLabel(t *testing.T) {
+	server := newTestServer(t, `
+asn: 64496
+routerid: 192.0.2.255
+routers:
+  rt-bogons:
+    neighbors: ["192.0.2.1"]
+`)
+	r := server.Routers["rt-bogons"]
+	before := testutil.ToFloat64(bogonPathsReceived.WithLabelValues("rt-bogons", string(BogonASN)))
+	r.countBogons(newTestPath(t, testRoute{prefix: "193.196.0.0/15", nexthop: "198.51.100.1", aspath: []uint32{64512}}))
+	if after := testutil.ToFloat64(bogonPathsReceived.WithLabelValues("rt-bogons", string(BogonASN))); after != before+1 {
+		t.Errorf("bogon ASN counter of rt-bogons went from %f to %f", before, after)
+	}
+	if unnamed := testutil.ToFloat64(bogonPathsReceived.WithLabelValues("", string(BogonASN))); unnamed != 0 {
+		t.Errorf("bogons counted without router name: %f", unnamed)
+	}
+}
@@ -0,0 +1,180 @@
+package routeinfo
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// CommunityInfo describes the meaning of a community. The fields mirror the
+// "tags" section of the looking glass config.js.
+type CommunityInfo struct {
+	Description string `yaml:"description" json:"description"`
+	Class       string `yaml:"class" json:"class"`
+}
+
+// AnnotatedCommunity is a standard or large community of a path together with
+// its entry from the community dictionary, if there is one.
+type AnnotatedCommunity struct {
+	Community   string `json:"community"`
+	Description string `json:"description,omitempty"`
+	Class       string `json:"class,omitempty"`
+}
+
+// Well-known communities from the IANA registry, these are always known to
+// the dictionary and can be overridden by the community file.
+var wellKnownCommunities = map[string]CommunityInfo{
+	"65535:0":     {Description: "GRACEFUL_SHUTDOWN", Class: "action-restrict"},   // RFC 8326
+	"65535:1":     {Description: "ACCEPT_OWN", Class: "action-restrict"},          // RFC 7611
+	"65535:6":     {Description: "LLGR_STALE", Class: "action-restrict"},          // RFC 9494
+	"65535:7":     {Description: "NO_LLGR", Class: "action-restrict"},             // RFC 9494
+	"65535:666":   {Description: "BLACKHOLE", Class: "action-blackhole"},          // RFC 7999
+	"65535:65281": {Description: "NO_EXPORT", Class: "action-restrict"},           // RFC 1997
+	"65535:65282": {Description: "NO_ADVERTISE", Class: "action-restrict"},        // RFC 1997
+	"65535:65283": {Description: "NO_EXPORT_SUBCONFED", Class: "action-restrict"}, // RFC 1997
+	"65535:65284": {Description: "NOPEER", Class: "action-restrict"},              // RFC 3765
+}
+
+type communityPattern struct {
+	pattern   string
+	fields    []string
+	wildcards int
+	info      CommunityInfo
+}
+
+// CommunityDictionary maps standard and large communities to their meaning.
+// Patterns may contain an 'x' for any single digit (i.e. "553:1xxx" matches
+// 553:1000 to 553:1999) or consist of a lone '*' in a field to match any
+// value of that field. Exact entries take precedence over patterns, and
+// patterns with fewer wildcards take precedence over broader ones.
+type CommunityDictionary struct {
+	exact    map[string]CommunityInfo
+	patterns []communityPattern
+}
+
+// NewCommunityDictionary returns a dictionary containing the well-known
+// communities and the given entries.
+func NewCommunityDictionary(entries map[string]CommunityInfo) *CommunityDictionary {
+	d := &CommunityDictionary{exact: make(map[string]CommunityInfo)}
+	for community, info := range wellKnownCommunities {
+		d.exact[community] = info
+	}
+	for pattern, info := range entries {
+		d.Add(pattern, info)
+	}
+	return d
+}
+
+// LoadCommunityDictionary reads a YAML file mapping communities or patterns to
+// a description and class and returns a dictionary with these entries in
+// addition to the well-known communities.
+func LoadCommunityDictionary(filename string) (*CommunityDictionary, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]CommunityInfo
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("parsing community file %s: %w", filename, err)
+	}
+	return NewCommunityDictionary(entries), nil
+}
+
+// Add adds a single community or pattern to the dictionary.
+func (d *CommunityDictionary) Add(pattern string, info CommunityInfo) {
+	if !strings.ContainsAny(pattern, "x*") {
+		d.exact[pattern] = info
+		return
+	}
+	p := communityPattern{
+		pattern: pattern,
+		fields:  strings.Split(pattern, ":"),
+		info:    info,
+	}
+	for _, field := range p.fields {
+		if field == "*" {
+			// a whole field is broader than any number of digit wildcards
+			p.wildcards += 10
+		} else {
+			p.wildcards += strings.Count(field, "x")
+		}
+	}
+	d.patterns = append(d.patterns, p)
+	sort.SliceStable(d.patterns, func(i, j int) bool {
+		if d.patterns[i].wildcards != d.patterns[j].wildcards {
+			return d.patterns[i].wildcards < d.patterns[j].wildcards
+		}
+		return d.patterns[i].pattern < d.patterns[j].pattern
+	})
+}
+
+// Lookup returns the meaning of a community in the "asn:value" or
+// "asn:data1:data2" notation.
+func (d *CommunityDictionary) Lookup(community string) (CommunityInfo, bool) {
+	if d == nil {
+		return CommunityInfo{}, false
+	}
+	if info, ok := d.exact[community]; ok {
+		return info, true
+	}
+	fields := strings.Split(community, ":")
+	for _, p := range d.patterns {
+		if p.matches(fields) {
+			return p.info, true
+		}
+	}
+	return CommunityInfo{}, false
+}
+
+// Entries returns all exact entries and patterns of the dictionary, i.e. for
+// rendering the looking glass configuration.
+func (d *CommunityDictionary) Entries() map[string]CommunityInfo {
+	entries := make(map[string]CommunityInfo)
+	if d == nil {
+		return entries
+	}
+	for community, info := range d.exact {
+		entries[community] = info
+	}
+	for _, p := range d.patterns {
+		entries[p.pattern] = p.info
+	}
+	return entries
+}
+
+// Annotate returns the communities with their dictionary entries attached.
+func (d *CommunityDictionary) Annotate(communities []string) []AnnotatedCommunity {
+	var annotated []AnnotatedCommunity
+	for _, community := range communities {
+		a := AnnotatedCommunity{Community: community}
+		if info, ok := d.Lookup(community); ok {
+			a.Description = info.Description
+			a.Class = info.Class
+		}
+		annotated = append(annotated, a)
+	}
+	return annotated
+}
+
+func (p communityPattern) matches(fields []string) bool {
+	if len(fields) != len(p.fields) {
+		return false
+	}
+	for i, pf := range p.fields {
+		if pf == "*" {
+			continue
+		}
+		if len(pf) != len(fields[i]) {
+			return false
+		}
+		for j := 0; j < len(pf); j++ {
+			if pf[j] != 'x' && pf[j] != fields[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package routeinfo
+
+import "testing"
+
+func TestCommunityDictionaryLookup(t *testing.T) {
+	d := NewCommunityDictionary(map[string]CommunityInfo{
+		"553:1xxx":   {Description: "learned at PoP", Class: "info"},
+		"553:1234":   {Description: "learned at Stuttgart", Class: "info-location"},
+		"553:*":      {Description: "BelWü", Class: "info"},
+		"553:2:xx":   {Description: "prepend to transit", Class: "action"},
+		"65535:666":  {Description: "RTBH", Class: "action-blackhole"},
+		"553:10:100": {Description: "large exact", Class: "info"},
+	})
+
+	tests := []struct {
+		community   string
+		description string
+		found       bool
+	}{
+		{"65535:65281", "NO_EXPORT", true},
+		{"65535:666", "RTBH", true},
+		{"553:1234", "learned at Stuttgart", true},
+		{"553:1999", "learned at PoP", true},
+		{"553:19999", "BelWü", true},
+		{"553:42", "BelWü", true},
+		{"553:2:17", "prepend to transit", true},
+		{"553:2:170", "", false},
+		{"553:10:100", "large exact", true},
+		{"3320:1234", "", false},
+	}
+	for _, test := range tests {
+		info, found := d.Lookup(test.community)
+		if found != test.found || info.Description != test.description {
+			t.Errorf("Lookup(%s) = %q, %t; want %q, %t", test.community, info.Description, found, test.description, test.found)
+		}
+	}
+}
+
+func TestCommunityDictionaryAnnotate(t *testing.T) {
+	var d *CommunityDictionary
+	annotated := d.Annotate([]string{"65535:666"})
+	if len(annotated) != 1 || annotated[0].Description != "" {
+		t.Errorf("nil dictionary annotated %+v", annotated)
+	}
+
+	d = NewCommunityDictionary(nil)
+	annotated = d.Annotate([]string{"65535:666", "553:1"})
+	if len(annotated) != 2 || annotated[0].Class != "action-blackhole" || annotated[1].Class != "" {
+		t.Errorf("unexpected annotation %+v", annotated)
+	}
+}
@@ -0,0 +1,119 @@
+package routeinfo
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// comparedAttributes are the attributes of best paths checked by Compare, in
+// the order differences are reported.
+var comparedAttributes = []struct {
+	name  string
+	equal func(a *RouteInfo, b *RouteInfo) bool
+}{
+	{"prefix", func(a *RouteInfo, b *RouteInfo) bool { return a.Prefix == b.Prefix }},
+	{"nexthop", func(a *RouteInfo, b *RouteInfo) bool { return a.NextHop == b.NextHop }},
+	{"localpref", func(a *RouteInfo, b *RouteInfo) bool { return a.LocalPref == b.LocalPref }},
+	{"med", func(a *RouteInfo, b *RouteInfo) bool { return a.Med == b.Med }},
+	{"origin", func(a *RouteInfo, b *RouteInfo) bool { return a.Origin == b.Origin }},
+	{"aspath", func(a *RouteInfo, b *RouteInfo) bool { return slices.Equal(a.AsPath, b.AsPath) }},
+	{"communities", func(a *RouteInfo, b *RouteInfo) bool { return equalSets(a.Communities, b.Communities) }},
+	{"largecommunities", func(a *RouteInfo, b *RouteInfo) bool { return equalSets(a.LargeCommunities, b.LargeCommunities) }},
+}
+
+func equalSets(a []string, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// ComparisonGroup are routers which agree on the best path of a prefix.
+type ComparisonGroup struct {
+	Routers []string  `json:"routers"`
+	Best    RouteInfo `json:"best"`
+	// Differences are the attributes in which the best path differs from the
+	// one of the first group.
+	Differences []string `json:"differences"`
+}
+
+// PrefixComparison compares the best paths of a prefix on multiple routers.
+type PrefixComparison struct {
+	Prefix string            `json:"prefix"`
+	Groups []ComparisonGroup `json:"groups"`
+	// Missing are the routers without any path for the prefix, or without a
+	// best path.
+	Missing []string `json:"missing"`
+	// Differences are all attributes in which the best paths differ.
+	Differences []string `json:"differences"`
+}
+
+// Compare looks up a prefix on the given routers, or all routers if there are
+// none, and groups them by their best path. Groups are ordered by size, with
+// routers being in the given order or sorted by name.
+func (rs *RouteInfoServer) Compare(prefix string, routers ...string) (PrefixComparison, error) {
+	if len(routers) == 0 {
+		routers = slices.Sorted(maps.Keys(rs.Routers))
+	}
+	comparison := PrefixComparison{Prefix: prefix}
+	for _, name := range routers {
+		router, ok := rs.Routers[name]
+		if !ok {
+			return comparison, fmt.Errorf("unknown router %s", name)
+		}
+		var best *RouteInfo
+		for _, path := range router.Lookup(prefix) {
+			if path.Best {
+				best = &path
+				break
+			}
+		}
+		if best == nil {
+			comparison.Missing = append(comparison.Missing, name)
+			continue
+		}
+		found := false
+		for i := range comparison.Groups {
+			if len(bestPathDifferences(&comparison.Groups[i].Best, best)) == 0 {
+				comparison.Groups[i].Routers = append(comparison.Groups[i].Routers, name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			comparison.Groups = append(comparison.Groups, ComparisonGroup{Routers: []string{name}, Best: *best})
+		}
+	}
+
+	slices.SortStableFunc(comparison.Groups, func(a, b ComparisonGroup) int {
+		return len(b.Routers) - len(a.Routers)
+	})
+	differences := make(map[string]bool)
+	for i := range comparison.Groups {
+		if i > 0 {
+			comparison.Groups[i].Differences = bestPathDifferences(&comparison.Groups[0].Best, &comparison.Groups[i].Best)
+		}
+		for j := i + 1; j < len(comparison.Groups); j++ {
+			for _, difference := range bestPathDifferences(&comparison.Groups[i].Best, &comparison.Groups[j].Best) {
+				differences[difference] = true
+			}
+		}
+	}
+	for _, attribute := range comparedAttributes {
+		if differences[attribute.name] {
+			comparison.Differences = append(comparison.Differences, attribute.name)
+		}
+	}
+	return comparison, nil
+}
+
+func bestPathDifferences(a *RouteInfo, b *RouteInfo) []string {
+	var differences []string
+	for _, attribute := range comparedAttributes {
+		if !attribute.equal(a, b) {
+			differences = append(differences, attribute.name)
+		}
+	}
+	return differences
+}
@@ -0,0 +1,51 @@
+package routeinfo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	server := RouteInfoServer{Routers: map[string]*Router{
+		"a": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100, communities: []uint32{64500<<16 | 1, 64500<<16 | 2}},
+		),
+		"b": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100, communities: []uint32{64500<<16 | 2, 64500<<16 | 1}},
+		),
+		"c": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.2", aspath: []uint32{64502, 64501}, localPref: 200},
+		),
+		"d": newTestRouter(t),
+	}}
+
+	comparison, err := server.Compare("192.0.2.1", "c", "a", "b", "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comparison.Groups) != 2 {
+		t.Fatalf("got %d groups", len(comparison.Groups))
+	}
+	if !slices.Equal(comparison.Groups[0].Routers, []string{"a", "b"}) || !slices.Equal(comparison.Groups[1].Routers, []string{"c"}) {
+		t.Errorf("unexpected groups %v and %v", comparison.Groups[0].Routers, comparison.Groups[1].Routers)
+	}
+	want := []string{"nexthop", "localpref", "aspath", "communities"}
+	if !slices.Equal(comparison.Differences, want) || !slices.Equal(comparison.Groups[1].Differences, want) {
+		t.Errorf("unexpected differences %v", comparison.Differences)
+	}
+	if !slices.Equal(comparison.Missing, []string{"d"}) {
+		t.Errorf("unexpected missing routers %v", comparison.Missing)
+	}
+
+	comparison, err = server.Compare("192.0.2.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(comparison.Groups[0].Routers, []string{"a", "b"}) || comparison.Groups[0].Differences != nil {
+		t.Errorf("unexpected first group %+v", comparison.Groups[0])
+	}
+
+	if _, err := server.Compare("192.0.2.0/24", "e"); err == nil {
+		t.Error("unknown router did not fail")
+	}
+}
@@ -0,0 +1,258 @@
+package routeinfo
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// Table holds the best path of each prefix in the RIB of a router.
+type Table map[string]RouteInfo
+
+// Snapshot copies the best paths of all prefixes in the RIB. Prefixes without
+// a best path are left out.
+func (r *Router) Snapshot() Table {
+	table := make(Table)
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		for _, path := range paths {
+			if path.Best {
+				table[prefix] = path
+				break
+			}
+		}
+	})
+	return table
+}
+
+// TableDiff is a prefix whose best path differs between two tables. A or B is
+// nil if the prefix is missing in that table.
+type TableDiff struct {
+	Prefix string     `json:"prefix"`
+	A      *RouteInfo `json:"a"`
+	B      *RouteInfo `json:"b"`
+	// Differences are the attributes in which the best paths differ, see
+	// RouteInfoServer.Compare.
+	Differences []string `json:"differences"`
+}
+
+// diffBestPaths returns the difference of the best paths of a prefix, ok is
+// false if there is none.
+func diffBestPaths(prefix string, a RouteInfo, inA bool, b RouteInfo, inB bool) (diff TableDiff, ok bool) {
+	diff.Prefix = prefix
+	switch {
+	case !inA && !inB:
+		return diff, false
+	case !inB:
+		diff.A = &a
+	case !inA:
+		diff.B = &b
+	default:
+		diff.Differences = bestPathDifferences(&a, &b)
+		if len(diff.Differences) == 0 {
+			return diff, false
+		}
+		diff.A, diff.B = &a, &b
+	}
+	return diff, true
+}
+
+// BestPaths are the best paths of a router, either its current RIB or a
+// Table taken before.
+type BestPaths interface {
+	// WalkBest calls fn with the best path of each prefix until fn returns
+	// false.
+	WalkBest(fn func(path RouteInfo) bool)
+	// Best returns the best path of a prefix.
+	Best(prefix string) (RouteInfo, bool)
+}
+
+func (t Table) WalkBest(fn func(path RouteInfo) bool) {
+	for _, path := range t {
+		if !fn(path) {
+			return
+		}
+	}
+}
+
+func (t Table) Best(prefix string) (RouteInfo, bool) {
+	path, ok := t[prefix]
+	return path, ok
+}
+
+func (r *Router) WalkBest(fn func(path RouteInfo) bool) {
+	r.WalkUntil(func(prefix string, paths []RouteInfo) bool {
+		for _, path := range paths {
+			if path.Best {
+				return fn(path)
+			}
+		}
+		return true
+	})
+}
+
+// Best looks up the best path of a prefix. Unlike Lookup, only the best path is
+// converted and missing prefixes are not logged, as diffs look up every prefix.
+func (r *Router) Best(prefix string) (RouteInfo, bool) {
+	parsed, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return RouteInfo{}, false
+	}
+	family := bgp.RF_IPv4_UC
+	if parsed.Addr().Is6() {
+		family = bgp.RF_IPv6_UC
+	}
+	var (
+		best  RouteInfo
+		found bool
+	)
+	err = r.GobgpServer.ListPath(apiutil.ListPathRequest{
+		TableType: api.TableType_TABLE_TYPE_GLOBAL,
+		Family:    family,
+		Prefixes:  []*apiutil.LookupPrefix{{Prefix: prefix, LookupOption: apiutil.LOOKUP_EXACT}},
+	}, func(nlri bgp.NLRI, paths []*apiutil.Path) {
+		for _, path := range paths {
+			if path.Best && nlri.String() == prefix {
+				best, found = r.routeInfoFromPath(prefix, path), true
+				return
+			}
+		}
+	})
+	if err != nil {
+		r.Logger.GetApplicationLogger().Debugf("Failed looking up %s on router %s due to %v", prefix, r.Name, err)
+	}
+	return best, found
+}
+
+// DiffBestPaths calls fn with each prefix missing in a or b or with a different
+// best path, until fn returns false. Instead of copying both, the prefixes of a
+// are looked up in b and those of b missing in a looked up in a, so the
+// differences are found in no particular order. Snapshots of the same router
+// taken at different times can be compared as well as two routers.
+func DiffBestPaths(a BestPaths, b BestPaths, fn func(diff TableDiff) bool) {
+	stopped := false
+	a.WalkBest(func(pathA RouteInfo) bool {
+		pathB, inB := b.Best(pathA.Prefix)
+		if diff, ok := diffBestPaths(pathA.Prefix, pathA, true, pathB, inB); ok {
+			stopped = !fn(diff)
+		}
+		return !stopped
+	})
+	if stopped {
+		return
+	}
+	b.WalkBest(func(pathB RouteInfo) bool {
+		if _, inA := a.Best(pathB.Prefix); inA {
+			return true
+		}
+		return fn(TableDiff{Prefix: pathB.Prefix, B: &pathB})
+	})
+}
+
+// snapshotStore keeps the named snapshots of the routers.
+type snapshotStore struct {
+	mutex  sync.Mutex
+	tables map[snapshotKey]*storedSnapshot
+}
+
+type snapshotKey struct {
+	router string
+	name   string
+}
+
+type storedSnapshot struct {
+	table Table
+	time  time.Time
+}
+
+// SnapshotInfo describes a snapshot kept by TakeSnapshot.
+type SnapshotInfo struct {
+	Router   string    `json:"router"`
+	Name     string    `json:"name"`
+	Time     time.Time `json:"time"`
+	Prefixes int       `json:"prefixes"`
+}
+
+// TakeSnapshot keeps a snapshot of the best paths of a router under a name,
+// replacing an older one of the same name. It can be compared with later
+// states of the router by DiffTables as "<router>@<name>".
+func (rs *RouteInfoServer) TakeSnapshot(router string, name string) error {
+	r, ok := rs.Routers[router]
+	if !ok {
+		return fmt.Errorf("unknown router %s", router)
+	}
+	if name == "" || strings.Contains(name, "@") {
+		return fmt.Errorf("invalid snapshot name %q", name)
+	}
+	snapshot := &storedSnapshot{table: r.Snapshot(), time: time.Now()}
+
+	rs.snapshots.mutex.Lock()
+	defer rs.snapshots.mutex.Unlock()
+	if rs.snapshots.tables == nil {
+		rs.snapshots.tables = make(map[snapshotKey]*storedSnapshot)
+	}
+	rs.snapshots.tables[snapshotKey{router: router, name: name}] = snapshot
+	return nil
+}
+
+// Snapshots lists the snapshots kept, ordered by router and name.
+func (rs *RouteInfoServer) Snapshots() []SnapshotInfo {
+	rs.snapshots.mutex.Lock()
+	defer rs.snapshots.mutex.Unlock()
+	var snapshots []SnapshotInfo
+	for key, snapshot := range rs.snapshots.tables {
+		snapshots = append(snapshots, SnapshotInfo{
+			Router:   key.router,
+			Name:     key.name,
+			Time:     snapshot.time,
+			Prefixes: len(snapshot.table),
+		})
+	}
+	slices.SortFunc(snapshots, func(a, b SnapshotInfo) int {
+		return cmp.Or(strings.Compare(a.Router, b.Router), strings.Compare(a.Name, b.Name))
+	})
+	return snapshots
+}
+
+// bestPaths returns the current best paths of a router, or those of one of
+// its snapshots for "<router>@<name>".
+func (rs *RouteInfoServer) bestPaths(reference string) (BestPaths, error) {
+	router, name, isSnapshot := strings.Cut(reference, "@")
+	r, ok := rs.Routers[router]
+	if !ok {
+		return nil, fmt.Errorf("unknown router %s", router)
+	}
+	if !isSnapshot {
+		return r, nil
+	}
+	rs.snapshots.mutex.Lock()
+	defer rs.snapshots.mutex.Unlock()
+	snapshot, ok := rs.snapshots.tables[snapshotKey{router: router, name: name}]
+	if !ok {
+		return nil, fmt.Errorf("unknown snapshot %s of router %s", name, router)
+	}
+	return snapshot.table, nil
+}
+
+// DiffTables compares the best paths of two routers, see DiffBestPaths. Each
+// of them may also refer to a snapshot taken by TakeSnapshot as
+// "<router>@<name>".
+func (rs *RouteInfoServer) DiffTables(a string, b string, fn func(diff TableDiff) bool) error {
+	pathsA, err := rs.bestPaths(a)
+	if err != nil {
+		return err
+	}
+	pathsB, err := rs.bestPaths(b)
+	if err != nil {
+		return err
+	}
+	DiffBestPaths(pathsA, pathsB, fn)
+	return nil
+}
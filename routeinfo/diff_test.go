@@ -0,0 +1,99 @@
+package routeinfo
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffTables(t *testing.T) {
+	server := RouteInfoServer{Routers: map[string]*Router{
+		"a": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+			testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500}, localPref: 100},
+			testRoute{prefix: "203.0.113.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500}, localPref: 100},
+		),
+		"b": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+			testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.2", aspath: []uint32{64500}, localPref: 100},
+			testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500}, localPref: 100},
+		),
+	}}
+
+	var diffs []TableDiff
+	if err := server.DiffTables("a", "b", func(diff TableDiff) bool {
+		diffs = append(diffs, diff)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(diffs, func(a, b TableDiff) int { return strings.Compare(a.Prefix, b.Prefix) })
+	if len(diffs) != 3 {
+		t.Fatalf("got %d differences: %+v", len(diffs), diffs)
+	}
+	if diffs[0].Prefix != "198.51.100.0/24" || !slices.Equal(diffs[0].Differences, []string{"nexthop"}) {
+		t.Errorf("unexpected difference %+v", diffs[0])
+	}
+	if diffs[1].Prefix != "2001:db8::/32" || diffs[1].A != nil || diffs[1].B == nil {
+		t.Errorf("unexpected difference %+v", diffs[1])
+	}
+	if diffs[2].Prefix != "203.0.113.0/24" || diffs[2].A == nil || diffs[2].B != nil {
+		t.Errorf("unexpected difference %+v", diffs[2])
+	}
+
+	count := 0
+	err := server.DiffTables("a", "b", func(TableDiff) bool {
+		count++
+		return false
+	})
+	if err != nil || count != 1 {
+		t.Errorf("diff did not stop after the first prefix, got %d prefixes", count)
+	}
+
+	if err := server.DiffTables("a", "c", func(TableDiff) bool { return true }); err == nil {
+		t.Error("unknown router did not fail")
+	}
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	server := RouteInfoServer{Routers: map[string]*Router{
+		"a": newTestRouter(t,
+			testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		),
+	}}
+	if err := server.TakeSnapshot("a", "before"); err != nil {
+		t.Fatal(err)
+	}
+	snapshots := server.Snapshots()
+	if len(snapshots) != 1 || snapshots[0].Router != "a" || snapshots[0].Name != "before" || snapshots[0].Prefixes != 1 {
+		t.Fatalf("unexpected snapshots %+v", snapshots)
+	}
+
+	var diffs []TableDiff
+	collect := func(diff TableDiff) bool {
+		diffs = append(diffs, diff)
+		return true
+	}
+	if err := server.DiffTables("a@before", "a", collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("snapshot differs from the unchanged router: %+v", diffs)
+	}
+
+	// a table taken before the prefix was learned
+	server.snapshots.tables[snapshotKey{router: "a", name: "empty"}] = &storedSnapshot{table: Table{}}
+	if err := server.DiffTables("a@empty", "a", collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 1 || diffs[0].Prefix != "192.0.2.0/24" || diffs[0].A != nil || diffs[0].B == nil {
+		t.Errorf("unexpected differences %+v", diffs)
+	}
+
+	if err := server.DiffTables("a@missing", "a", collect); err == nil {
+		t.Error("unknown snapshot did not fail")
+	}
+	if err := server.TakeSnapshot("a", "x@y"); err == nil {
+		t.Error("invalid snapshot name did not fail")
+	}
+}
@@ -0,0 +1,114 @@
+package routeinfo
+
+import (
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	// a path for a monitored prefix, a more specific or a covering prefix
+	// is originated by an AS not allowed to
+	EventUnexpectedOrigin EventType = "unexpected-origin"
+	// a path for a more specific of a monitored prefix exceeds its maximum
+	// length
+	EventMaxLengthExceeded EventType = "max-length-exceeded"
+	// the last path for one of our own prefixes was withdrawn, or none was
+	// received until the end of RIB
+	EventPrefixMissing EventType = "prefix-missing"
+	// the best path of a watched prefix changed or it was announced again
+	EventBestPathChanged EventType = "best-path-changed"
+	// a watched prefix has no best path anymore
+	EventWithdrawn EventType = "withdrawn"
+	// the best path of a watched prefix became RPKI invalid
+	EventRPKIInvalid EventType = "rpki-invalid"
+	// a neighbor left the Established state
+	EventNeighborDown EventType = "neighbor-down"
+)
+
+// Event is raised by a router for something worth telling someone about.
+type Event struct {
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	Router  string    `json:"router"`
+	Prefix  string    `json:"prefix"`
+	Message string    `json:"message"`
+	// Path is the path which caused the event, if any.
+	Path *RouteInfo `json:"path,omitempty"`
+}
+
+// eventStream passes events to all subscribers. Subscribers not keeping up
+// miss events instead of blocking BGP processing.
+type eventStream struct {
+	lock        sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{subscribers: make(map[chan Event]struct{})}
+}
+
+func (s *eventStream) publish(event Event) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+func (s *eventStream) subscribe(buffer int) (<-chan Event, func()) {
+	events := make(chan Event, buffer)
+	s.lock.Lock()
+	s.subscribers[events] = struct{}{}
+	s.lock.Unlock()
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			s.lock.Lock()
+			delete(s.subscribers, events)
+			s.lock.Unlock()
+			close(events)
+		})
+	}
+}
+
+func (r *Router) newEvent(eventType EventType, path *RouteInfo, message string) Event {
+	event := Event{
+		Type:    eventType,
+		Time:    time.Now(),
+		Router:  r.Name,
+		Message: message,
+		Path:    path,
+	}
+	if path != nil {
+		event.Prefix = path.Prefix
+	}
+	return event
+}
+
+// raise logs an event and passes it to all subscribers.
+func (r *Router) raise(event Event) {
+	r.Logger.GetApplicationLogger().Warnf("Router %s: %s", event.Router, event.Message)
+	r.events.publish(event)
+}
+
+// Subscribe returns a channel receiving the events of all routers, and a
+// function to end the subscription which closes the channel. Events are
+// dropped if more than buffer events are pending.
+func (rs *RouteInfoServer) Subscribe(buffer int) (<-chan Event, func()) {
+	return rs.eventStream().subscribe(buffer)
+}
+
+func (rs *RouteInfoServer) eventStream() *eventStream {
+	rs.eventsOnce.Do(func() {
+		rs.events = newEventStream()
+	})
+	return rs.events
+}
@@ -0,0 +1,22 @@
+package routeinfo
+
+import "testing"
+
+func TestEventRouterName(t *testing.T) {
+	server := newTestServer(t, `
+asn: 64496
+routerid: 192.0.2.255
+routers:
+  rt-1:
+    neighbors: ["192.0.2.1"]
+`)
+	events, unsubscribe := server.Subscribe(1)
+	defer unsubscribe()
+
+	r := server.Routers["rt-1"]
+	r.raise(r.newEvent(EventWithdrawn, &RouteInfo{Prefix: "198.51.100.0/24"}, "withdrawn"))
+	event := <-events
+	if event.Router != "rt-1" || event.Prefix != "198.51.100.0/24" {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
@@ -0,0 +1,178 @@
+package routeinfo
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"strconv"
+)
+
+// ExportFormats are the formats supported by Router.Export.
+var ExportFormats = []string{"csv", "jsonl", "ip2asn", "pmacct"}
+
+// ExportEntry maps a prefix to the origin AS, AS path and next hop of its best
+// path.
+type ExportEntry struct {
+	Prefix   string   `json:"prefix"`
+	OriginAs uint32   `json:"originas"`
+	AsPath   []uint32 `json:"aspath"`
+	NextHop  string   `json:"nexthop"`
+}
+
+// Export writes the best path of each prefix in the RIB as
+//
+//   - csv: prefix, origin AS, AS path and next hop with a header line
+//   - jsonl: one ExportEntry per line
+//   - ip2asn: the TSV format of iptoasn.com, with more specifics cut out of
+//     the ranges of less specific prefixes
+//   - pmacct: a networks_file for pmacct with peer AS, origin AS and prefix
+//
+// Locally originated prefixes are mapped to the AS of the router.
+func (r *Router) Export(w io.Writer, format string) error {
+	if !slices.Contains(ExportFormats, format) {
+		return fmt.Errorf("unknown export format %s", format)
+	}
+	buffered := bufio.NewWriter(w)
+	var err error
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(buffered)
+		writer.Write([]string{"prefix", "originas", "aspath", "nexthop"})
+		r.exportEntries(func(entry ExportEntry) {
+			writer.Write([]string{entry.Prefix, strconv.FormatUint(uint64(entry.OriginAs), 10), formatASPath(entry.AsPath), entry.NextHop})
+		})
+		writer.Flush()
+		err = writer.Error()
+	case "jsonl":
+		encoder := json.NewEncoder(buffered)
+		r.exportEntries(func(entry ExportEntry) {
+			if err == nil {
+				err = encoder.Encode(entry)
+			}
+		})
+	case "ip2asn":
+		var prefixes []prefixOrigin
+		r.exportEntries(func(entry ExportEntry) {
+			if prefix, err := netip.ParsePrefix(entry.Prefix); err == nil {
+				prefixes = append(prefixes, prefixOrigin{prefix: prefix.Masked(), asn: entry.OriginAs})
+			}
+		})
+		for _, rng := range flattenPrefixes(prefixes) {
+			fmt.Fprintf(buffered, "%s\t%s\t%d\tNone\tUnknown\n", rng.start, rng.end, rng.asn)
+		}
+	case "pmacct":
+		r.exportEntries(func(entry ExportEntry) {
+			peerAs := entry.OriginAs
+			if len(entry.AsPath) > 0 {
+				peerAs = entry.AsPath[0]
+			}
+			fmt.Fprintf(buffered, "%d,%d,%s\n", peerAs, entry.OriginAs, entry.Prefix)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func (r *Router) exportEntries(fn func(entry ExportEntry)) {
+	r.walkBest(func(path RouteInfo) {
+		fn(ExportEntry{Prefix: path.Prefix, OriginAs: path.OriginAs, AsPath: path.AsPath, NextHop: path.NextHop})
+	})
+}
+
+// walkBest calls fn with the best path of each prefix, with the AS of the
+// router as origin of locally originated prefixes.
+func (r *Router) walkBest(fn func(path RouteInfo)) {
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		for _, path := range paths {
+			if !path.Best {
+				continue
+			}
+			if len(path.AsPath) == 0 {
+				path.OriginAs = r.Asn
+			}
+			fn(path)
+			return
+		}
+	})
+}
+
+type prefixOrigin struct {
+	prefix netip.Prefix
+	asn    uint32
+}
+
+type addrRange struct {
+	start netip.Addr
+	end   netip.Addr
+	asn   uint32
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// flattenPrefixes turns possibly nested prefixes into sorted, disjoint address
+// ranges, where more specifics take precedence. Adjacent ranges of the same
+// AS are merged.
+func flattenPrefixes(prefixes []prefixOrigin) []addrRange {
+	slices.SortFunc(prefixes, func(a, b prefixOrigin) int {
+		if c := a.prefix.Addr().Compare(b.prefix.Addr()); c != 0 {
+			return c
+		}
+		return a.prefix.Bits() - b.prefix.Bits()
+	})
+
+	var ranges []addrRange
+	emit := func(start netip.Addr, end netip.Addr, asn uint32) {
+		if last := len(ranges) - 1; last >= 0 && ranges[last].asn == asn && ranges[last].end.Next() == start {
+			ranges[last].end = end
+			return
+		}
+		ranges = append(ranges, addrRange{start: start, end: end, asn: asn})
+	}
+
+	type active struct {
+		end netip.Addr
+		asn uint32
+	}
+	var (
+		stack  []active
+		cursor netip.Addr // first address not emitted yet, invalid if all are
+	)
+	// pop emits the rest of the innermost active prefix and removes it
+	pop := func() {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if cursor.IsValid() && cursor.BitLen() == top.end.BitLen() && cursor.Compare(top.end) <= 0 {
+			emit(cursor, top.end, top.asn)
+			cursor = top.end.Next()
+		}
+	}
+	for _, p := range prefixes {
+		start, end := p.prefix.Addr(), lastAddr(p.prefix)
+		for len(stack) > 0 && (stack[len(stack)-1].end.BitLen() != start.BitLen() || stack[len(stack)-1].end.Less(start)) {
+			pop()
+		}
+		if len(stack) > 0 && cursor.IsValid() && cursor.Less(start) {
+			emit(cursor, start.Prev(), stack[len(stack)-1].asn)
+		}
+		cursor = start
+		stack = append(stack, active{end: end, asn: p.asn})
+	}
+	for len(stack) > 0 {
+		pop()
+	}
+	return ranges
+}
@@ -0,0 +1,76 @@
+package routeinfo
+
+import (
+	"bytes"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	r := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500}, localPref: 100},
+	)
+
+	var b bytes.Buffer
+	if err := r.Export(&b, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || lines[0] != "prefix,originas,aspath,nexthop" {
+		t.Fatalf("unexpected csv export %q", b.String())
+	}
+	if !slices.Contains(lines, "192.0.2.0/24,64501,64500 64501,198.51.100.1") {
+		t.Errorf("missing IPv4 prefix in csv export %q", b.String())
+	}
+
+	b.Reset()
+	if err := r.Export(&b, "pmacct"); err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSpace(b.String()), "\n")
+	slices.Sort(lines)
+	if !slices.Equal(lines, []string{"64500,64500,2001:db8::/32", "64500,64501,192.0.2.0/24"}) {
+		t.Errorf("unexpected pmacct export %q", b.String())
+	}
+
+	b.Reset()
+	if err := r.Export(&b, "ip2asn"); err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSpace(b.String()), "\n")
+	if !slices.Equal(lines, []string{"192.0.2.0\t192.0.2.255\t64501\tNone\tUnknown", "2001:db8::\t2001:db8:ffff:ffff:ffff:ffff:ffff:ffff\t64500\tNone\tUnknown"}) {
+		t.Errorf("unexpected ip2asn export %q", b.String())
+	}
+
+	if err := r.Export(&b, "mrt"); err == nil {
+		t.Error("unknown format did not fail")
+	}
+}
+
+func TestFlattenPrefixes(t *testing.T) {
+	prefixes := []prefixOrigin{
+		{netip.MustParsePrefix("10.0.0.0/8"), 64500},
+		{netip.MustParsePrefix("10.1.0.0/16"), 64501},
+		{netip.MustParsePrefix("10.1.2.0/24"), 64502},
+		{netip.MustParsePrefix("10.2.0.0/16"), 64500},
+		{netip.MustParsePrefix("255.255.255.0/24"), 64503},
+	}
+	var got []string
+	for _, rng := range flattenPrefixes(prefixes) {
+		got = append(got, rng.start.String()+"-"+rng.end.String())
+	}
+	want := []string{
+		"10.0.0.0-10.0.255.255",
+		"10.1.0.0-10.1.1.255",
+		"10.1.2.0-10.1.2.255",
+		"10.1.3.0-10.1.255.255",
+		"10.2.0.0-10.255.255.255",
+		"255.255.255.0-255.255.255.255",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,252 @@
+package routeinfo
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// GraphFormats are the formats ASGraph can be rendered in.
+var GraphFormats = []string{"dot", "svg", "mermaid"}
+
+// ASNode is an AS in an ASGraph.
+type ASNode struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Name  string `json:"name,omitempty"`
+	// Depth is the number of hops from our own AS, not counting prepends.
+	Depth int `json:"depth"`
+}
+
+// ASEdge connects two ASes of an ASGraph. Edges from an AS to itself are
+// prepends, or local paths if they are at our own AS.
+type ASEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Best is true if the edge is part of a best path.
+	Best bool `json:"best"`
+}
+
+// ASGraph is the AS level tree of the paths to a prefix as seen from our own
+// AS, like the graph drawn by the looking glass.
+type ASGraph struct {
+	Prefix string   `json:"prefix"`
+	Nodes  []ASNode `json:"nodes"`
+	Edges  []ASEdge `json:"edges"`
+}
+
+// graphLocalID is the ID of the node of our own AS.
+const graphLocalID = "local"
+
+// GraphOptions are the settings of an ASGraph, corresponding to those of the
+// graph of the looking glass.
+type GraphOptions struct {
+	LocalName       string // label of our own AS
+	DrawLocalASLoop bool   // add an edge from our own AS to itself for local paths
+	DrawPrepends    bool   // add edges from an AS to itself for prepends
+}
+
+// NewASGraph builds the graph of the paths. Nodes and edges are in the order
+// they first appear in the paths.
+func NewASGraph(options GraphOptions, paths []RouteInfo) *ASGraph {
+	graph := &ASGraph{}
+	nodes := make(map[string]int)
+	edges := make(map[[2]string]int)
+	addNode := func(node ASNode) {
+		if i, ok := nodes[node.ID]; ok {
+			graph.Nodes[i].Depth = max(graph.Nodes[i].Depth, node.Depth)
+			return
+		}
+		nodes[node.ID] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	addEdge := func(from string, to string, best bool) {
+		if i, ok := edges[[2]string{from, to}]; ok {
+			graph.Edges[i].Best = graph.Edges[i].Best || best
+			return
+		}
+		edges[[2]string{from, to}] = len(graph.Edges)
+		graph.Edges = append(graph.Edges, ASEdge{From: from, To: to, Best: best})
+	}
+
+	addNode(ASNode{ID: graphLocalID, Label: options.LocalName})
+	for _, path := range paths {
+		if graph.Prefix == "" {
+			graph.Prefix = path.Prefix
+		}
+		if len(path.AsPath) == 0 {
+			if options.DrawLocalASLoop {
+				addEdge(graphLocalID, graphLocalID, path.Best)
+			}
+			continue
+		}
+		previous, depth := graphLocalID, 0
+		for i, asn := range path.AsPath {
+			node := ASNode{ID: fmt.Sprintf("as%d", asn), Label: fmt.Sprintf("AS%d", asn)}
+			if i < len(path.AsPathNames) {
+				node.Name = path.AsPathNames[i].Name
+			}
+			if node.ID != previous {
+				depth++
+			}
+			node.Depth = depth
+			addNode(node)
+			if node.ID != previous || options.DrawPrepends {
+				addEdge(previous, node.ID, path.Best)
+			}
+			previous = node.ID
+		}
+	}
+	return graph
+}
+
+// Render returns the graph in one of the GraphFormats.
+func (g *ASGraph) Render(format string) (string, error) {
+	switch format {
+	case "dot":
+		return g.Dot(), nil
+	case "svg":
+		return g.SVG(), nil
+	case "mermaid":
+		return g.Mermaid(), nil
+	default:
+		return "", fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+// Dot returns the graph in the Graphviz DOT language.
+func (g *ASGraph) Dot() string {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph \"%s\" {\n", quote(g.Prefix))
+	b.WriteString("  rankdir=LR;\n  node [shape=box, style=rounded];\n")
+	for _, node := range g.Nodes {
+		label := quote(node.Label)
+		if node.Name != "" {
+			label += `\n` + quote(node.Name)
+		}
+		fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", node.ID, label)
+	}
+	for _, edge := range g.Edges {
+		style := "style=dashed, color=gray50"
+		if edge.Best {
+			style = "penwidth=2, color=darkgreen"
+		}
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\" [%s];\n", edge.From, edge.To, style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the graph as a mermaid flowchart, with dashed arrows for
+// paths which are not the best path.
+func (g *ASGraph) Mermaid() string {
+	quote := strings.NewReplacer(`"`, "#quot;").Replace
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		label := quote(node.Label)
+		if node.Name != "" {
+			label += "<br>" + quote(node.Name)
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", node.ID, label)
+	}
+	for _, edge := range g.Edges {
+		arrow := "-.->"
+		if edge.Best {
+			arrow = "-->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", edge.From, arrow, edge.To)
+	}
+	return b.String()
+}
+
+// layout of the SVG rendering in pixels
+const (
+	svgCharWidth  = 7
+	svgNodeHeight = 40
+	svgColumnGap  = 60
+	svgRowGap     = 20
+	svgMargin     = 40 // room for loops above the top row
+)
+
+// SVG returns a standalone SVG image of the graph. Nodes are placed in
+// columns by their depth.
+func (g *ASGraph) SVG() string {
+	type box struct{ x, y, width int }
+	var columns [][]int
+	widths := make([]int, len(g.Nodes))
+	for i, node := range g.Nodes {
+		for len(columns) <= node.Depth {
+			columns = append(columns, nil)
+		}
+		columns[node.Depth] = append(columns[node.Depth], i)
+		widths[i] = max(len(node.Label), len(node.Name))*svgCharWidth + 20
+	}
+	rows := 0
+	for _, column := range columns {
+		rows = max(rows, len(column))
+	}
+	height := rows*(svgNodeHeight+svgRowGap) - svgRowGap + 2*svgMargin
+
+	boxes := make([]box, len(g.Nodes))
+	x := svgMargin
+	for _, column := range columns {
+		columnWidth := 0
+		for _, i := range column {
+			columnWidth = max(columnWidth, widths[i])
+		}
+		// center each column vertically
+		y := (height - len(column)*(svgNodeHeight+svgRowGap) + svgRowGap) / 2
+		for _, i := range column {
+			boxes[i] = box{x: x + (columnWidth-widths[i])/2, y: y, width: widths[i]}
+			y += svgNodeHeight + svgRowGap
+		}
+		x += columnWidth + svgColumnGap
+	}
+	width := x - svgColumnGap + svgMargin
+	index := make(map[string]int)
+	for i, node := range g.Nodes {
+		index[node.ID] = i
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(g.Prefix))
+	b.WriteString(`<defs>` +
+		`<marker id="best" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#2e7d32"/></marker>` +
+		`<marker id="other" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#808080"/></marker>` +
+		"</defs>\n")
+	for _, edge := range g.Edges {
+		from, to := boxes[index[edge.From]], boxes[index[edge.To]]
+		var d string
+		if edge.From == edge.To {
+			// loop above the node
+			x1, x2 := from.x+from.width/3, from.x+2*from.width/3
+			d = fmt.Sprintf("M%d,%d C%d,%d %d,%d %d,%d", x1, from.y, x1, from.y-30, x2, from.y-30, x2, from.y)
+		} else {
+			x1, y1 := from.x+from.width, from.y+svgNodeHeight/2
+			x2, y2 := to.x, to.y+svgNodeHeight/2
+			d = fmt.Sprintf("M%d,%d C%d,%d %d,%d %d,%d", x1, y1, (x1+x2)/2, y1, (x1+x2)/2, y2, x2, y2)
+		}
+		if edge.Best {
+			fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="#2e7d32" stroke-width="2" marker-end="url(#best)"/>`+"\n", d)
+		} else {
+			fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="#808080" stroke-dasharray="4,3" marker-end="url(#other)"/>`+"\n", d)
+		}
+	}
+	for i, node := range g.Nodes {
+		box := boxes[i]
+		fmt.Fprintf(&b, `<g><rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#f5f5f5" stroke="#404040"/>`, box.x, box.y, box.width, svgNodeHeight)
+		center := box.x + box.width/2
+		if node.Name == "" {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%s</text>`, center, box.y+svgNodeHeight/2+4, html.EscapeString(node.Label))
+		} else {
+			fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%s</text>`, center, box.y+16, html.EscapeString(node.Label))
+			fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" font-size="10">%s</text>`, center, box.y+31, html.EscapeString(node.Name))
+		}
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
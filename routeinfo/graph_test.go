@@ -0,0 +1,136 @@
+package routeinfo
+
+import (
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/BelWue/bgp_routeinfo/asnames"
+)
+
+func testGraph() *ASGraph {
+	return NewASGraph(GraphOptions{LocalName: "Our \"Net\"", DrawLocalASLoop: true, DrawPrepends: true}, graphTestPaths)
+}
+
+var graphTestPaths = []RouteInfo{
+	{Prefix: "192.0.2.0/24", AsPath: []uint32{64500, 64501}, Best: true,
+		AsPathNames: []asnames.Name{{Asn: 64500, Name: "TRANSIT"}, {Asn: 64501}}},
+	{Prefix: "192.0.2.0/24", AsPath: []uint32{64502, 64502, 64501}},
+	{Prefix: "192.0.2.0/24"},
+}
+
+func TestNewASGraph(t *testing.T) {
+	graph := testGraph()
+	if graph.Prefix != "192.0.2.0/24" {
+		t.Errorf("unexpected prefix %s", graph.Prefix)
+	}
+	expectedNodes := []ASNode{
+		{ID: "local", Label: "Our \"Net\""},
+		{ID: "as64500", Label: "AS64500", Name: "TRANSIT", Depth: 1},
+		{ID: "as64501", Label: "AS64501", Depth: 2},
+		{ID: "as64502", Label: "AS64502", Depth: 1},
+	}
+	if !slices.Equal(graph.Nodes, expectedNodes) {
+		t.Errorf("unexpected nodes %+v", graph.Nodes)
+	}
+	expectedEdges := []ASEdge{
+		{From: "local", To: "as64500", Best: true},
+		{From: "as64500", To: "as64501", Best: true},
+		{From: "local", To: "as64502"},
+		{From: "as64502", To: "as64502"},
+		{From: "as64502", To: "as64501"},
+		{From: "local", To: "local"},
+	}
+	if !slices.Equal(graph.Edges, expectedEdges) {
+		t.Errorf("unexpected edges %+v", graph.Edges)
+	}
+}
+
+func TestNewASGraphWithoutLoops(t *testing.T) {
+	graph := NewASGraph(GraphOptions{LocalName: "Our Net"}, graphTestPaths)
+	expectedEdges := []ASEdge{
+		{From: "local", To: "as64500", Best: true},
+		{From: "as64500", To: "as64501", Best: true},
+		{From: "local", To: "as64502"},
+		{From: "as64502", To: "as64501"},
+	}
+	if !slices.Equal(graph.Edges, expectedEdges) {
+		t.Errorf("unexpected edges %+v", graph.Edges)
+	}
+	if len(graph.Nodes) != 4 {
+		t.Errorf("unexpected nodes %+v", graph.Nodes)
+	}
+}
+
+func TestASGraphRender(t *testing.T) {
+	graph := testGraph()
+
+	mermaid, err := graph.Render("mermaid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `flowchart LR
+  local["Our #quot;Net#quot;"]
+  as64500["AS64500<br>TRANSIT"]
+  as64501["AS64501"]
+  as64502["AS64502"]
+  local --> as64500
+  as64500 --> as64501
+  local -.-> as64502
+  as64502 -.-> as64502
+  as64502 -.-> as64501
+  local -.-> local
+`
+	if mermaid != expected {
+		t.Errorf("unexpected mermaid graph:\n%s", mermaid)
+	}
+
+	dot, err := graph.Render("dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`digraph "192.0.2.0/24" {`,
+		`"local" [label="Our \"Net\""];`,
+		`"as64500" [label="AS64500\nTRANSIT"];`,
+		`"local" -> "as64500" [penwidth=2, color=darkgreen];`,
+		`"as64502" -> "as64502" [style=dashed, color=gray50];`,
+	} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("dot graph does not contain %s:\n%s", line, dot)
+		}
+	}
+
+	svg, err := graph.Render("svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	paths, texts := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid svg: %v", err)
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			switch element.Name.Local {
+			case "path":
+				paths++
+			case "text":
+				texts++
+			}
+		}
+	}
+	// two arrowheads and an edge each, a line per label and name
+	if paths != 2+len(graph.Edges) || texts != 5 {
+		t.Errorf("unexpected number of paths %d and texts %d in svg:\n%s", paths, texts, svg)
+	}
+
+	if _, err := graph.Render("png"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,216 @@
+package routeinfo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func formatCommunity(community uint32) string {
+	return fmt.Sprintf("%d:%d", community>>16, community&0xffff)
+}
+
+// formatExtendedCommunity prefixes the value with "rt" for route targets,
+// "soo" for route origins and the hex type and subtype otherwise.
+func formatExtendedCommunity(ec bgp.ExtendedCommunityInterface) string {
+	ecType, ecSubType := ec.GetTypes()
+	switch ecSubType {
+	case bgp.EC_SUBTYPE_ROUTE_TARGET:
+		return "rt:" + ec.String()
+	case bgp.EC_SUBTYPE_ROUTE_ORIGIN:
+		return "soo:" + ec.String()
+	}
+	return fmt.Sprintf("0x%02x%02x:%s", uint8(ecType), uint8(ecSubType), ec.String())
+}
+
+// communityKeys returns all standard, large and extended communities of a
+// path, prefixed with their kind.
+func communityKeys(path *apiutil.Path) []string {
+	var keys []string
+	for _, a := range path.Attrs {
+		switch attr := a.(type) {
+		case *bgp.PathAttributeCommunities:
+			for _, community := range attr.Value {
+				keys = append(keys, "c:"+formatCommunity(community))
+			}
+		case *bgp.PathAttributeLargeCommunities:
+			for _, community := range attr.Values {
+				keys = append(keys, "lc:"+community.String())
+			}
+		case *bgp.PathAttributeExtendedCommunities:
+			for _, ec := range attr.Value {
+				keys = append(keys, "ec:"+formatExtendedCommunity(ec))
+			}
+		}
+	}
+	return keys
+}
+
+type indexPathKey struct {
+	peer   string
+	prefix string
+	id     uint32
+}
+
+// communityIndex maps the communities of all paths in the RIB of a router to
+// the prefixes carrying them. It is kept up to date from BGP updates.
+type communityIndex struct {
+	lock     sync.RWMutex
+	prefixes map[string]map[string]int // community key -> prefix -> number of paths
+	paths    map[indexPathKey][]string // path -> community keys
+}
+
+func newCommunityIndex() *communityIndex {
+	return &communityIndex{
+		prefixes: make(map[string]map[string]int),
+		paths:    make(map[indexPathKey][]string),
+	}
+}
+
+// update adds, replaces or withdraws a path received in an update.
+func (i *communityIndex) update(path *apiutil.Path) {
+	if path.Nlri == nil {
+		// end of RIB marker
+		return
+	}
+	key := indexPathKey{peer: path.PeerAddress.String(), prefix: path.Nlri.String(), id: path.RemoteID}
+	var keys []string
+	if !path.Withdrawal {
+		keys = communityKeys(path)
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.remove(key)
+	if len(keys) == 0 {
+		return
+	}
+	i.paths[key] = keys
+	for _, k := range keys {
+		if i.prefixes[k] == nil {
+			i.prefixes[k] = make(map[string]int)
+		}
+		i.prefixes[k][key.prefix]++
+	}
+}
+
+// dropPeer removes all paths of a peer whose session went down.
+func (i *communityIndex) dropPeer(peer string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for key := range i.paths {
+		if key.peer == peer {
+			i.remove(key)
+		}
+	}
+}
+
+func (i *communityIndex) remove(key indexPathKey) {
+	for _, k := range i.paths[key] {
+		i.prefixes[k][key.prefix]--
+		if i.prefixes[k][key.prefix] <= 0 {
+			delete(i.prefixes[k], key.prefix)
+		}
+		if len(i.prefixes[k]) == 0 {
+			delete(i.prefixes, k)
+		}
+	}
+	delete(i.paths, key)
+}
+
+// lookup returns the sorted prefixes with at least one path carrying the
+// community key.
+func (i *communityIndex) lookup(key string) []string {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	prefixes := make([]string, 0, len(i.prefixes[key]))
+	for prefix := range i.prefixes[key] {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+	return prefixes
+}
+
+var (
+	standardCommunityRegexp = regexp.MustCompile(`^\d+:\d+$`)
+	largeCommunityRegexp    = regexp.MustCompile(`^\d+:\d+:\d+$`)
+	extendedCommunityRegexp = regexp.MustCompile(`^(rt|soo|0x[0-9a-f]{4}):.+$`)
+)
+
+// communitySearchKeys resolves a community in standard, large or extended
+// notation, or the description of a community in the dictionary, to index
+// keys.
+func (r *Router) communitySearchKeys(community string) ([]string, error) {
+	switch {
+	case standardCommunityRegexp.MatchString(community):
+		return []string{"c:" + community}, nil
+	case largeCommunityRegexp.MatchString(community):
+		return []string{"lc:" + community}, nil
+	case extendedCommunityRegexp.MatchString(community):
+		return []string{"ec:" + community}, nil
+	}
+	var keys []string
+	for c, info := range r.communities.Entries() {
+		if strings.EqualFold(info.Description, community) && !strings.ContainsAny(c, "x*") {
+			if largeCommunityRegexp.MatchString(c) {
+				keys = append(keys, "lc:"+c)
+			} else {
+				keys = append(keys, "c:"+c)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown community %s", community)
+	}
+	return keys, nil
+}
+
+// PrefixesWithCommunity calls fn with all paths carrying a community. The
+// community is given as "asn:value", "asn:data1:data2", as extended community
+// like "rt:asn:value" or by its description in the community dictionary, i.e.
+// "BLACKHOLE".
+func (r *Router) PrefixesWithCommunity(community string, fn func(path RouteInfo)) error {
+	keys, err := r.communitySearchKeys(community)
+	if err != nil {
+		return err
+	}
+	var prefixes []string
+	for _, key := range keys {
+		prefixes = append(prefixes, r.communityIndex.lookup(key)...)
+	}
+	slices.Sort(prefixes)
+	prefixes = slices.Compact(prefixes)
+
+	for _, prefix := range prefixes {
+		for _, path := range r.Lookup(prefix) {
+			if path.hasCommunityKey(keys) {
+				fn(path)
+			}
+		}
+	}
+	return nil
+}
+
+func (path *RouteInfo) hasCommunityKey(keys []string) bool {
+	for _, key := range keys {
+		kind, community, _ := strings.Cut(key, ":")
+		var communities []string
+		switch kind {
+		case "c":
+			communities = path.Communities
+		case "lc":
+			communities = path.LargeCommunities
+		case "ec":
+			communities = path.ExtendedCommunities
+		}
+		if slices.Contains(communities, community) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package routeinfo
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestCommunityIndex(t *testing.T) {
+	index := newCommunityIndex()
+	update := func(peer string, prefix string, id uint32, withdrawal bool, communities ...uint32) {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		index.update(&apiutil.Path{
+			Nlri:        nlri,
+			PeerAddress: netip.MustParseAddr(peer),
+			RemoteID:    id,
+			Withdrawal:  withdrawal,
+			Attrs:       []bgp.PathAttributeInterface{bgp.NewPathAttributeCommunities(communities)},
+		})
+	}
+	blackhole := uint32(65535<<16 | 666)
+	other := uint32(553<<16 | 1)
+
+	update("192.0.2.1", "198.51.100.1/32", 1, false, blackhole)
+	update("192.0.2.1", "198.51.100.1/32", 2, false, blackhole, other)
+	update("192.0.2.2", "198.51.100.2/32", 1, false, blackhole)
+	if prefixes := index.lookup("c:65535:666"); !slices.Equal(prefixes, []string{"198.51.100.1/32", "198.51.100.2/32"}) {
+		t.Fatalf("got %v", prefixes)
+	}
+
+	// implicit withdraw of the community by replacing the path
+	update("192.0.2.1", "198.51.100.1/32", 1, false, other)
+	if prefixes := index.lookup("c:65535:666"); len(prefixes) != 2 {
+		t.Fatalf("prefix removed while a path still carries the community: %v", prefixes)
+	}
+	update("192.0.2.1", "198.51.100.1/32", 2, true)
+	if prefixes := index.lookup("c:65535:666"); !slices.Equal(prefixes, []string{"198.51.100.2/32"}) {
+		t.Fatalf("got %v after withdraw", prefixes)
+	}
+
+	index.dropPeer("192.0.2.2")
+	if prefixes := index.lookup("c:65535:666"); len(prefixes) != 0 {
+		t.Fatalf("got %v after peer went down", prefixes)
+	}
+	if prefixes := index.lookup("c:553:1"); !slices.Equal(prefixes, []string{"198.51.100.1/32"}) {
+		t.Fatalf("got %v for remaining community", prefixes)
+	}
+}
+
+func TestPrefixesWithCommunity(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.1/32", nexthop: "198.51.100.1", aspath: []uint32{64500}, localPref: 100, communities: []uint32{65535<<16 | 666}},
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500}, localPref: 100, communities: []uint32{553<<16 | 1}},
+	)
+	for _, query := range []string{"65535:666", "blackhole"} {
+		window := NewPathWindow(0, 10)
+		if err := router.PrefixesWithCommunity(query, window.Add); err != nil {
+			t.Fatal(err)
+		}
+		if paths := window.Paths(); len(paths) != 1 || paths[0].Prefix != "192.0.2.1/32" {
+			t.Errorf("%s: got %+v", query, paths)
+		}
+	}
+	if err := router.PrefixesWithCommunity("no-such-community", func(RouteInfo) {}); err == nil {
+		t.Error("unknown community name did not fail")
+	}
+}
@@ -0,0 +1,295 @@
+package routeinfo
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+)
+
+// RouteObject is an RPSL route or route6 object.
+type RouteObject struct {
+	Prefix netip.Prefix `json:"prefix"`
+	Origin uint32       `json:"origin"`
+	Source string       `json:"source"`
+}
+
+// IRRResult is the IRR validation of a path.
+type IRRResult struct {
+	// RouteObject is true if a route object for the prefix and origin exists.
+	RouteObject bool `json:"routeobject"`
+	// Origins are the origins of all route objects for the prefix.
+	Origins []uint32 `json:"origins"`
+	// ASSet is the as-set expected for the neighbor AS, empty if none is
+	// configured, and InASSet whether the origin is a member of it.
+	ASSet   string `json:"asset"`
+	InASSet bool   `json:"inasset"`
+}
+
+// IRRValidator checks paths against route objects and as-sets loaded from
+// RPSL dumps of IRR databases, i.e. https://ftp.ripe.net/ripe/dbase/split/ripe.db.route.gz.
+// Only the as-sets configured for neighbor ASes are expanded.
+type IRRValidator struct {
+	Files   []string          `yaml:"files"`   // RPSL dumps, gzip compressed if ending in .gz
+	ASSets  map[uint32]string `yaml:"assets"`  // as-set expected for paths received from a neighbor AS
+	Refresh int               `yaml:"refresh"` // seconds between reloads of the files, defaults to 86400
+
+	Logger log.RouteinfoLogger
+
+	lock    sync.RWMutex
+	routes  map[netip.Prefix][]RouteObject
+	members map[string]map[uint32]struct{}
+	stop    chan struct{}
+}
+
+// Start loads the configured files and reloads them in the background until
+// Stop is called.
+func (v *IRRValidator) Start() error {
+	v.stop = make(chan struct{})
+	if len(v.Files) == 0 {
+		return fmt.Errorf("irr: no files configured")
+	}
+	if err := v.LoadFiles(v.Files...); err != nil {
+		return err
+	}
+	refresh := time.Duration(v.Refresh) * time.Second
+	if refresh <= 0 {
+		refresh = 86400 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-v.stop:
+				return
+			case <-ticker.C:
+				if err := v.LoadFiles(v.Files...); err != nil {
+					v.logger().Errorf("Failed to reload IRR files: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (v *IRRValidator) Stop() {
+	if v.stop != nil {
+		close(v.stop)
+		v.stop = nil
+	}
+}
+
+// LoadFiles replaces the current route objects and as-sets with those from the
+// files.
+func (v *IRRValidator) LoadFiles(filenames ...string) error {
+	routes := make(map[netip.Prefix][]RouteObject)
+	sets := make(map[string][]string)
+	count := 0
+	for _, filename := range filenames {
+		n, err := loadRPSLFile(filename, routes, sets)
+		if err != nil {
+			return fmt.Errorf("parsing IRR file %s: %w", filename, err)
+		}
+		count += n
+	}
+	members := make(map[string]map[uint32]struct{})
+	for _, name := range v.ASSets {
+		name = strings.ToUpper(name)
+		if _, ok := sets[name]; !ok {
+			v.logger().Warnf("as-set %s not found in IRR files", name)
+		}
+		members[name] = make(map[uint32]struct{})
+		expandASSet(name, sets, members[name], make(map[string]bool))
+	}
+	v.lock.Lock()
+	v.routes = routes
+	v.members = members
+	v.lock.Unlock()
+	v.logger().Infof("Loaded %d route objects and %d as-sets from %s", count, len(sets), strings.Join(filenames, ", "))
+	return nil
+}
+
+func loadRPSLFile(filename string, routes map[netip.Prefix][]RouteObject, sets map[string][]string) (int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	var reader io.Reader = file
+	if strings.HasSuffix(filename, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	count := 0
+	err = parseRPSL(reader, func(object [][2]string) {
+		switch object[0][0] {
+		case "route", "route6":
+			prefix, err := netip.ParsePrefix(object[0][1])
+			if err != nil {
+				return
+			}
+			route := RouteObject{Prefix: prefix.Masked()}
+			for _, attr := range object[1:] {
+				switch attr[0] {
+				case "origin":
+					if asn, ok := parseRPSLASN(attr[1]); ok {
+						route.Origin = asn
+					}
+				case "source":
+					route.Source = strings.ToUpper(attr[1])
+				}
+			}
+			if route.Origin != 0 {
+				routes[route.Prefix] = append(routes[route.Prefix], route)
+				count++
+			}
+		case "as-set":
+			name := strings.ToUpper(object[0][1])
+			for _, attr := range object[1:] {
+				if attr[0] == "members" || attr[0] == "mp-members" {
+					for _, member := range strings.FieldsFunc(attr[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+						sets[name] = append(sets[name], strings.ToUpper(member))
+					}
+				}
+			}
+		}
+	})
+	return count, err
+}
+
+// parseRPSL calls fn with the attributes of each object, joining continuation
+// lines and removing comments.
+func parseRPSL(reader io.Reader, fn func(object [][2]string)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var object [][2]string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(object) > 0 {
+				fn(object)
+				object = nil
+			}
+			continue
+		}
+		if line[0] == '%' || line[0] == '#' {
+			continue
+		}
+		if comment := strings.IndexByte(line, '#'); comment >= 0 {
+			line = line[:comment]
+		}
+		switch {
+		case line[0] == ' ' || line[0] == '\t' || line[0] == '+':
+			if len(object) > 0 {
+				last := &object[len(object)-1]
+				last[1] = strings.TrimSpace(last[1] + " " + strings.TrimSpace(line[1:]))
+			}
+		default:
+			key, value, ok := strings.Cut(line, ":")
+			if ok {
+				object = append(object, [2]string{strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)})
+			}
+		}
+	}
+	if len(object) > 0 {
+		fn(object)
+	}
+	return scanner.Err()
+}
+
+// parseRPSLASN parses an AS number like AS64500.
+func parseRPSLASN(s string) (uint32, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, "AS") {
+		return 0, false
+	}
+	asn, err := strconv.ParseUint(s[2:], 10, 32)
+	return uint32(asn), err == nil
+}
+
+// expandASSet adds all ASes in an as-set and its nested as-sets to members.
+func expandASSet(name string, sets map[string][]string, members map[uint32]struct{}, visited map[string]bool) {
+	if visited[name] {
+		return
+	}
+	visited[name] = true
+	for _, member := range sets[name] {
+		if asn, ok := parseRPSLASN(member); ok {
+			members[asn] = struct{}{}
+		} else {
+			expandASSet(member, sets, members, visited)
+		}
+	}
+}
+
+// Count returns the number of prefixes with route objects.
+func (v *IRRValidator) Count() int {
+	if v == nil {
+		return 0
+	}
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return len(v.routes)
+}
+
+// Covering returns all route objects whose prefix covers the given prefix.
+func (v *IRRValidator) Covering(prefix netip.Prefix) []RouteObject {
+	if v == nil {
+		return nil
+	}
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	var covering []RouteObject
+	for bits := 0; bits <= prefix.Bits(); bits++ {
+		p, err := prefix.Addr().Prefix(bits)
+		if err != nil {
+			break
+		}
+		covering = append(covering, v.routes[p]...)
+	}
+	return covering
+}
+
+// Validate checks whether a route object exists for the prefix and origin,
+// and whether the origin is a member of the as-set expected for the neighbor
+// AS. It returns nil if no route objects are loaded.
+func (v *IRRValidator) Validate(prefix netip.Prefix, originAs uint32, neighborAs uint32) *IRRResult {
+	if v.Count() == 0 {
+		return nil
+	}
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	result := &IRRResult{}
+	for _, route := range v.routes[prefix.Masked()] {
+		if !slices.Contains(result.Origins, route.Origin) {
+			result.Origins = append(result.Origins, route.Origin)
+		}
+		result.RouteObject = result.RouteObject || route.Origin == originAs
+	}
+	if set, ok := v.ASSets[neighborAs]; ok {
+		result.ASSet = strings.ToUpper(set)
+		_, result.InASSet = v.members[result.ASSet][originAs]
+	}
+	return result
+}
+
+func (v *IRRValidator) logger() log.ApplicationLogger {
+	if v.Logger == nil {
+		v.Logger = &log.DefaultRouteInfoLogger{}
+	}
+	return v.Logger.GetApplicationLogger()
+}
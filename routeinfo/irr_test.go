@@ -0,0 +1,102 @@
+package routeinfo
+
+import (
+	"compress/gzip"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const testRPSL = `% This is a dump of an IRR database
+# with comments
+
+route:          192.0.2.0/24
+descr:          Example
+origin:         AS64501 # customer
+mnt-by:         EXAMPLE-MNT
+source:         RIPE
+
+route6:         2001:db8::/32
+origin:         AS64500
+source:         RIPE
+
+as-set:         AS64500:AS-CUSTOMERS
+members:        AS64501,
+                AS-DOWNSTREAM
++               AS64502
+source:         RIPE
+
+as-set:         AS-DOWNSTREAM
+members:        AS64503, AS64500:AS-CUSTOMERS
+source:         RIPE
+`
+
+func writeIRRFile(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "ripe.db.gz")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	if _, err := gz.Write([]byte(testRPSL)); err != nil {
+		t.Fatal(err)
+	}
+	gz.Close()
+	f.Close()
+	return file
+}
+
+func TestIRRValidator(t *testing.T) {
+	v := &IRRValidator{ASSets: map[uint32]string{64500: "as64500:as-customers"}}
+	if err := v.LoadFiles(writeIRRFile(t)); err != nil {
+		t.Fatal(err)
+	}
+	if v.Count() != 2 {
+		t.Errorf("got %d prefixes with route objects", v.Count())
+	}
+
+	prefix := netip.MustParsePrefix("192.0.2.0/24")
+	result := v.Validate(prefix, 64501, 64500)
+	if !result.RouteObject || !slices.Equal(result.Origins, []uint32{64501}) || result.ASSet != "AS64500:AS-CUSTOMERS" || !result.InASSet {
+		t.Errorf("unexpected result %+v", result)
+	}
+	// member of a nested as-set, but no route object
+	result = v.Validate(prefix, 64503, 64500)
+	if result.RouteObject || !result.InASSet {
+		t.Errorf("unexpected result %+v", result)
+	}
+	// no as-set for the neighbor
+	result = v.Validate(netip.MustParsePrefix("198.51.100.0/24"), 64504, 64510)
+	if result.RouteObject || len(result.Origins) != 0 || result.ASSet != "" || result.InASSet {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	covering := v.Covering(netip.MustParsePrefix("2001:db8:1::/48"))
+	if len(covering) != 1 || covering[0] != (RouteObject{Prefix: netip.MustParsePrefix("2001:db8::/32"), Origin: 64500, Source: "RIPE"}) {
+		t.Errorf("unexpected covering route objects %+v", covering)
+	}
+
+	var empty *IRRValidator
+	if empty.Validate(prefix, 64501, 64500) != nil {
+		t.Error("got result without IRR data")
+	}
+}
+
+func TestRouteInfoIRR(t *testing.T) {
+	r := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64502}, localPref: 100},
+	)
+	r.irr = &IRRValidator{ASSets: map[uint32]string{64500: "AS64500:AS-CUSTOMERS"}}
+	if err := r.irr.LoadFiles(writeIRRFile(t)); err != nil {
+		t.Fatal(err)
+	}
+	paths := r.Lookup("192.0.2.0/24")
+	if len(paths) != 1 || paths[0].IRR == nil {
+		t.Fatalf("unexpected paths %+v", paths)
+	}
+	if result := paths[0].IRR; result.RouteObject || !slices.Equal(result.Origins, []uint32{64501}) || !result.InASSet {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
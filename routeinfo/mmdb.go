@@ -0,0 +1,339 @@
+package routeinfo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// mmdbNode is a node of the search tree of a MaxMind DB. Nodes without
+// children are leafs pointing to data, or to no data if data is negative.
+type mmdbNode struct {
+	children [2]*mmdbNode
+	data     int
+	id       int
+}
+
+func newMMDBNode(data int) *mmdbNode {
+	return &mmdbNode{data: data, id: -1}
+}
+
+func (n *mmdbNode) leaf() bool {
+	return n.children[0] == nil
+}
+
+// split turns a leaf into a node with two leafs pointing to its data.
+func (n *mmdbNode) split() {
+	n.children = [2]*mmdbNode{newMMDBNode(n.data), newMMDBNode(n.data)}
+	n.data = -1
+}
+
+// mmdbWriter builds an IPv6 MaxMind DB as specified in
+// https://maxmind.github.io/MaxMind-DB/, with IPv4 networks in ::/96 and
+// ::ffff:0:0/96 pointing to them.
+type mmdbWriter struct {
+	databaseType string
+	description  string
+	root         *mmdbNode
+	data         []byte
+	offsets      map[string]int
+}
+
+func newMMDBWriter(databaseType string, description string) *mmdbWriter {
+	return &mmdbWriter{
+		databaseType: databaseType,
+		description:  description,
+		root:         newMMDBNode(-1),
+		offsets:      make(map[string]int),
+	}
+}
+
+// insert sets the data of a network. Networks have to be inserted from less to
+// more specific, as data of more specifics is overwritten.
+func (w *mmdbWriter) insert(prefix netip.Prefix, record map[string]any) {
+	encoded := string(mmdbEncode(nil, record))
+	offset, ok := w.offsets[encoded]
+	if !ok {
+		offset = len(w.data)
+		w.offsets[encoded] = offset
+		w.data = append(w.data, encoded...)
+	}
+	address, bits := mmdbAddress(prefix)
+	node := w.path(address, bits)
+	node.children = [2]*mmdbNode{}
+	node.data = offset
+}
+
+// path returns the node for the first bits of the address, creating the nodes
+// on the way.
+func (w *mmdbWriter) path(address [16]byte, bits int) *mmdbNode {
+	node := w.root
+	for i := 0; i < bits; i++ {
+		if node.leaf() {
+			node.split()
+		}
+		node = node.children[address[i/8]>>(7-i%8)&1]
+	}
+	return node
+}
+
+// mmdbAddress returns the address of a prefix and its length in the tree.
+func mmdbAddress(prefix netip.Prefix) ([16]byte, int) {
+	if prefix.Addr().Is4() {
+		var address [16]byte
+		v4 := prefix.Addr().As4()
+		copy(address[12:], v4[:])
+		return address, prefix.Bits() + 96
+	}
+	return prefix.Addr().As16(), prefix.Bits()
+}
+
+// aliasIPv4 points ::ffff:0:0/96 to the IPv4 networks in ::/96.
+func (w *mmdbWriter) aliasIPv4() {
+	node := w.root
+	for i := 0; i < 96 && !node.leaf(); i++ {
+		node = node.children[0]
+	}
+	if node.leaf() {
+		return
+	}
+	mapped := netip.MustParseAddr("::ffff:0:0").As16()
+	parent := w.path(mapped, 95)
+	if parent.leaf() {
+		parent.split()
+	}
+	parent.children[1] = node
+}
+
+// WriteTo writes the database, returning the number of bytes written.
+func (w *mmdbWriter) WriteTo(out io.Writer) (int64, error) {
+	w.aliasIPv4()
+	if w.root.leaf() {
+		w.root.split()
+	}
+	var nodes []*mmdbNode
+	var number func(node *mmdbNode)
+	number = func(node *mmdbNode) {
+		if node.leaf() || node.id >= 0 {
+			return
+		}
+		node.id = len(nodes)
+		nodes = append(nodes, node)
+		number(node.children[0])
+		number(node.children[1])
+	}
+	number(w.root)
+
+	nodeCount := uint64(len(nodes))
+	var recordSize int
+	switch maxRecord := nodeCount + 16 + uint64(len(w.data)); {
+	case maxRecord < 1<<24:
+		recordSize = 24
+	case maxRecord < 1<<28:
+		recordSize = 28
+	case maxRecord < 1<<32:
+		recordSize = 32
+	default:
+		return 0, fmt.Errorf("database too large with %d nodes and %d bytes of data", nodeCount, len(w.data))
+	}
+	record := func(node *mmdbNode) uint32 {
+		switch {
+		case !node.leaf():
+			return uint32(node.id)
+		case node.data < 0:
+			return uint32(nodeCount)
+		default:
+			return uint32(nodeCount + 16 + uint64(node.data))
+		}
+	}
+
+	buffered := bufio.NewWriter(out)
+	var written int64
+	write := func(b []byte) {
+		n, _ := buffered.Write(b)
+		written += int64(n)
+	}
+	b := make([]byte, recordSize/4)
+	for _, node := range nodes {
+		left, right := record(node.children[0]), record(node.children[1])
+		switch recordSize {
+		case 24:
+			b[0], b[1], b[2] = byte(left>>16), byte(left>>8), byte(left)
+			b[3], b[4], b[5] = byte(right>>16), byte(right>>8), byte(right)
+		case 28:
+			b[0], b[1], b[2] = byte(left>>16), byte(left>>8), byte(left)
+			b[3] = byte(left>>24)<<4 | byte(right>>24)
+			b[4], b[5], b[6] = byte(right>>16), byte(right>>8), byte(right)
+		case 32:
+			binary.BigEndian.PutUint32(b[0:], left)
+			binary.BigEndian.PutUint32(b[4:], right)
+		}
+		write(b)
+	}
+	write(make([]byte, 16))
+	write(w.data)
+	write([]byte("\xab\xcd\xefMaxMind.com"))
+	write(mmdbEncode(nil, map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(time.Now().Unix()),
+		"database_type":               w.databaseType,
+		"description":                 map[string]any{"en": w.description},
+		"ip_version":                  uint16(6),
+		"languages":                   []string{"en"},
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(recordSize),
+	}))
+	return written, buffered.Flush()
+}
+
+// MaxMind DB data types
+const (
+	mmdbString = 2
+	mmdbUint16 = 5
+	mmdbUint32 = 6
+	mmdbMap    = 7
+	mmdbUint64 = 9
+	mmdbArray  = 11
+)
+
+// mmdbEncode appends a value of the types used by the writer to b.
+func mmdbEncode(b []byte, value any) []byte {
+	switch v := value.(type) {
+	case string:
+		b = mmdbControl(b, mmdbString, len(v))
+		return append(b, v...)
+	case uint16:
+		return mmdbUint(b, mmdbUint16, uint64(v))
+	case uint32:
+		return mmdbUint(b, mmdbUint32, uint64(v))
+	case uint64:
+		return mmdbUint(b, mmdbUint64, v)
+	case []string:
+		b = mmdbControl(b, mmdbArray, len(v))
+		for _, s := range v {
+			b = mmdbEncode(b, s)
+		}
+		return b
+	case []uint32:
+		b = mmdbControl(b, mmdbArray, len(v))
+		for _, u := range v {
+			b = mmdbEncode(b, u)
+		}
+		return b
+	case map[string]any:
+		b = mmdbControl(b, mmdbMap, len(v))
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			b = mmdbEncode(b, key)
+			b = mmdbEncode(b, v[key])
+		}
+		return b
+	}
+	panic(fmt.Sprintf("unsupported mmdb type %T", value))
+}
+
+// mmdbUint appends an unsigned integer with as few bytes as possible.
+func mmdbUint(b []byte, dataType int, v uint64) []byte {
+	size := 0
+	for x := v; x > 0; x >>= 8 {
+		size++
+	}
+	b = mmdbControl(b, dataType, size)
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
+
+// mmdbControl appends the control byte for a value of a type and size, and the
+// extended type and size bytes if needed.
+func mmdbControl(b []byte, dataType int, size int) []byte {
+	var control byte
+	if dataType <= 7 {
+		control = byte(dataType) << 5
+	}
+	var extra []byte
+	switch {
+	case size < 29:
+		control |= byte(size)
+	case size < 29+256:
+		control |= 29
+		extra = []byte{byte(size - 29)}
+	case size < 285+65536:
+		control |= 30
+		extra = []byte{byte((size - 285) >> 8), byte(size - 285)}
+	default:
+		control |= 31
+		extra = []byte{byte((size - 65821) >> 16), byte((size - 65821) >> 8), byte(size - 65821)}
+	}
+	b = append(b, control)
+	if dataType > 7 {
+		b = append(b, byte(dataType-7))
+	}
+	return append(b, extra...)
+}
+
+// WriteMMDB writes the best path of each prefix in the RIB to a MaxMind DB
+// file with the fields autonomous_system_number, as_path, next_hop, peer,
+// communities and large_communities, i.e. for the enrichment of flows. The
+// file is replaced atomically, so readers never see a partially written one.
+func (r *Router) WriteMMDB(path string) error {
+	type network struct {
+		prefix netip.Prefix
+		record map[string]any
+	}
+	var networks []network
+	r.walkBest(func(bestPath RouteInfo) {
+		prefix, err := netip.ParsePrefix(bestPath.Prefix)
+		if err != nil {
+			return
+		}
+		networks = append(networks, network{prefix: prefix.Masked(), record: map[string]any{
+			"autonomous_system_number": bestPath.OriginAs,
+			"as_path":                  bestPath.AsPath,
+			"next_hop":                 bestPath.NextHop,
+			"peer":                     bestPath.Peer,
+			"communities":              bestPath.Communities,
+			"large_communities":        bestPath.LargeCommunities,
+		}})
+	})
+	slices.SortFunc(networks, func(a, b network) int {
+		_, bitsA := mmdbAddress(a.prefix)
+		_, bitsB := mmdbAddress(b.prefix)
+		return bitsA - bitsB
+	})
+
+	writer := newMMDBWriter("RouteInfo-ASN", fmt.Sprintf("Best paths of router %s", r.Name))
+	for _, n := range networks {
+		writer.insert(n.prefix, n.record)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := writer.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,214 @@
+package routeinfo
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// mmdbDecode decodes the value at offset, returning it and the offset of the
+// next value. Only the types written by mmdbWriter are supported.
+func mmdbDecode(t *testing.T, b []byte, offset int) (any, int) {
+	control := b[offset]
+	offset++
+	dataType := int(control >> 5)
+	if dataType == 0 {
+		dataType = int(b[offset]) + 7
+		offset++
+	}
+	size := int(control & 0x1f)
+	switch size {
+	case 29:
+		size = 29 + int(b[offset])
+		offset++
+	case 30:
+		size = 285 + (int(b[offset])<<8 | int(b[offset+1]))
+		offset += 2
+	case 31:
+		size = 65821 + (int(b[offset])<<16 | int(b[offset+1])<<8 | int(b[offset+2]))
+		offset += 3
+	}
+	switch dataType {
+	case mmdbString:
+		return string(b[offset : offset+size]), offset + size
+	case mmdbUint16, mmdbUint32, mmdbUint64:
+		var v uint64
+		for _, x := range b[offset : offset+size] {
+			v = v<<8 | uint64(x)
+		}
+		return v, offset + size
+	case mmdbArray:
+		array := []any{}
+		for i := 0; i < size; i++ {
+			var value any
+			value, offset = mmdbDecode(t, b, offset)
+			array = append(array, value)
+		}
+		return array, offset
+	case mmdbMap:
+		m := make(map[string]any)
+		for i := 0; i < size; i++ {
+			var key, value any
+			key, offset = mmdbDecode(t, b, offset)
+			value, offset = mmdbDecode(t, b, offset)
+			m[key.(string)] = value
+		}
+		return m, offset
+	}
+	t.Fatalf("unexpected type %d at offset %d", dataType, offset)
+	return nil, 0
+}
+
+// mmdbLookup looks up an address like a MaxMind DB reader.
+func mmdbLookup(t *testing.T, db []byte, address netip.Addr) map[string]any {
+	marker := bytes.LastIndex(db, []byte("\xab\xcd\xefMaxMind.com"))
+	if marker < 0 {
+		t.Fatal("metadata not found")
+	}
+	decoded, _ := mmdbDecode(t, db, marker+14)
+	metadata := decoded.(map[string]any)
+	nodeCount := int(metadata["node_count"].(uint64))
+	recordSize := int(metadata["record_size"].(uint64))
+
+	bits := address.As16()
+	if address.Is4() {
+		bits = [16]byte{}
+		v4 := address.As4()
+		copy(bits[12:], v4[:])
+	}
+	node := 0
+	for i := 0; i < 128 && node < nodeCount; i++ {
+		record := db[node*recordSize/4 : (node+1)*recordSize/4]
+		bit := bits[i/8] >> (7 - i%8) & 1
+		switch recordSize {
+		case 24:
+			record = record[3*bit:]
+			node = int(record[0])<<16 | int(record[1])<<8 | int(record[2])
+		case 28:
+			if bit == 0 {
+				node = int(record[3]>>4)<<24 | int(record[0])<<16 | int(record[1])<<8 | int(record[2])
+			} else {
+				node = int(record[3]&0x0f)<<24 | int(record[4])<<16 | int(record[5])<<8 | int(record[6])
+			}
+		default:
+			t.Fatalf("unexpected record size %d", recordSize)
+		}
+	}
+	if node == nodeCount {
+		return nil
+	}
+	data, _ := mmdbDecode(t, db, nodeCount*recordSize/4+node-nodeCount)
+	return data.(map[string]any)
+}
+
+func TestWriteMMDB(t *testing.T) {
+	r := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100, communities: []uint32{64500<<16 | 1}},
+		testRoute{prefix: "192.0.2.128/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64502}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500}, localPref: 100},
+	)
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := r.WriteMMDB(path); err != nil {
+		t.Fatal(err)
+	}
+	db, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := mmdbLookup(t, db, netip.MustParseAddr("192.0.2.1"))
+	if record == nil || record["autonomous_system_number"] != uint64(64501) || record["next_hop"] != "198.51.100.1" ||
+		!slices.Equal(record["communities"].([]any), []any{"64500:1"}) {
+		t.Errorf("unexpected record %v for 192.0.2.1", record)
+	}
+	record = mmdbLookup(t, db, netip.MustParseAddr("::ffff:192.0.2.200"))
+	if record == nil || record["autonomous_system_number"] != uint64(64502) ||
+		!slices.Equal(record["as_path"].([]any), []any{uint64(64500), uint64(64502)}) {
+		t.Errorf("unexpected record %v for ::ffff:192.0.2.200", record)
+	}
+	record = mmdbLookup(t, db, netip.MustParseAddr("2001:db8::1"))
+	if record == nil || record["autonomous_system_number"] != uint64(64500) || record["peer"] == "" {
+		t.Errorf("unexpected record %v for 2001:db8::1", record)
+	}
+	if record := mmdbLookup(t, db, netip.MustParseAddr("198.51.100.1")); record != nil {
+		t.Errorf("unexpected record %v for 198.51.100.1", record)
+	}
+}
+
+// TestWriteMMDBReader reads the database with the reader of MaxMind.
+func TestWriteMMDBReader(t *testing.T) {
+	r := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100, communities: []uint32{64500<<16 | 1}},
+		testRoute{prefix: "192.0.2.128/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64502}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500}, localPref: 100},
+	)
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := r.WriteMMDB(path); err != nil {
+		t.Fatal(err)
+	}
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	type record struct {
+		Asn         uint32   `maxminddb:"autonomous_system_number"`
+		ASPath      []uint32 `maxminddb:"as_path"`
+		NextHop     string   `maxminddb:"next_hop"`
+		Peer        string   `maxminddb:"peer"`
+		Communities []string `maxminddb:"communities"`
+	}
+	for _, test := range []struct {
+		address string
+		network string
+		want    record
+	}{
+		{"192.0.2.1", "192.0.2.0/25", record{Asn: 64501, ASPath: []uint32{64500, 64501}, NextHop: "198.51.100.1", Communities: []string{"64500:1"}}},
+		{"192.0.2.200", "192.0.2.128/25", record{Asn: 64502, ASPath: []uint32{64500, 64502}, NextHop: "198.51.100.1", Communities: []string{}}},
+		{"2001:db8::1", "2001:db8::/32", record{Asn: 64500, ASPath: []uint32{64500}, NextHop: "2001:db8:ffff::1", Communities: []string{}}},
+	} {
+		var got record
+		network, ok, err := db.LookupNetwork(net.ParseIP(test.address), &got)
+		if err != nil || !ok {
+			t.Fatalf("%s: not found: %v", test.address, err)
+		}
+		got.Peer = ""
+		if network.String() != test.network || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v in %s, want %+v in %s", test.address, got, network, test.want, test.network)
+		}
+	}
+	var got record
+	if _, ok, err := db.LookupNetwork(net.ParseIP("198.51.100.1"), &got); err != nil || ok {
+		t.Errorf("unexpected record %+v for 198.51.100.1", got)
+	}
+}
+
+func TestMMDBControl(t *testing.T) {
+	for _, test := range []struct {
+		dataType int
+		size     int
+		want     []byte
+	}{
+		{mmdbString, 3, []byte{0x43}},
+		{mmdbString, 30, []byte{0x5d, 0x01}},
+		{mmdbString, 500, []byte{0x5e, 0x00, 0xd7}},
+		{mmdbString, 70000, []byte{0x5f, 0x00, 0x10, 0x53}},
+		{mmdbUint64, 8, []byte{0x08, 0x02}},
+		{mmdbArray, 2, []byte{0x02, 0x04}},
+	} {
+		if got := mmdbControl(nil, test.dataType, test.size); !bytes.Equal(got, test.want) {
+			t.Errorf("control of type %d and size %d is %x, want %x", test.dataType, test.size, got, test.want)
+		}
+	}
+}
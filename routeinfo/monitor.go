@@ -0,0 +1,251 @@
+package routeinfo
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"sync"
+
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// ExpectedPrefix declares which ASes may originate a prefix and its more
+// specifics, and up to which prefix length.
+type ExpectedPrefix struct {
+	Prefix      string   `yaml:"prefix" json:"prefix"`
+	Origins     []uint32 `yaml:"origins" json:"origins"`
+	MaxLength   int      `yaml:"maxlength" json:"maxlength"`
+	Description string   `yaml:"description" json:"description"`
+	prefix      netip.Prefix
+}
+
+// init parses the prefix and fills in defaults, the prefix length itself for
+// MaxLength and the given AS for Origins.
+func (e *ExpectedPrefix) init(asn uint32) error {
+	prefix, err := netip.ParsePrefix(e.Prefix)
+	if err != nil {
+		return err
+	}
+	e.prefix = prefix.Masked()
+	if e.MaxLength == 0 {
+		e.MaxLength = e.prefix.Bits()
+	}
+	if e.MaxLength < e.prefix.Bits() || e.MaxLength > e.prefix.Addr().BitLen() {
+		return fmt.Errorf("invalid maxlength %d for %s", e.MaxLength, e.Prefix)
+	}
+	if len(e.Origins) == 0 {
+		if asn == 0 {
+			return fmt.Errorf("no origins for %s", e.Prefix)
+		}
+		e.Origins = []uint32{asn}
+	}
+	return nil
+}
+
+// prefixMonitor checks the updates received by a router against our own and
+// our customers' prefixes. Events are raised once when a path starts to
+// violate an expectation, and again only after it was withdrawn or fixed.
+type prefixMonitor struct {
+	router   *Router
+	own      []*ExpectedPrefix
+	customer []*ExpectedPrefix
+
+	lock   sync.Mutex
+	alerts map[indexPathKey][]EventType
+	// paths currently received for each of our own prefixes
+	seen map[netip.Prefix]map[indexPathKey]struct{}
+	// own prefixes reported as missing and not received since
+	missing map[netip.Prefix]bool
+}
+
+func newPrefixMonitor(router *Router, own []*ExpectedPrefix, customer []*ExpectedPrefix) *prefixMonitor {
+	return &prefixMonitor{
+		router:   router,
+		own:      own,
+		customer: customer,
+		alerts:   make(map[indexPathKey][]EventType),
+		seen:     make(map[netip.Prefix]map[indexPathKey]struct{}),
+		missing:  make(map[netip.Prefix]bool),
+	}
+}
+
+// matching returns the expectations for a prefix. If it is within any, only
+// the most specific of those apply, so i.e. a customer prefix within one of
+// our own is not checked against our origins, nor ours as a covering prefix
+// of the customer's. Otherwise all expectations it covers apply.
+func (m *prefixMonitor) matching(prefix netip.Prefix) []*ExpectedPrefix {
+	expectations := append(slices.Clip(m.own), m.customer...)
+	longest := -1
+	for _, expected := range expectations {
+		if expected.prefix.Bits() <= prefix.Bits() && expected.prefix.Overlaps(prefix) {
+			longest = max(longest, expected.prefix.Bits())
+		}
+	}
+	var matching []*ExpectedPrefix
+	for _, expected := range expectations {
+		if !expected.prefix.Overlaps(prefix) || longest >= 0 && expected.prefix.Bits() != longest {
+			continue
+		}
+		matching = append(matching, expected)
+	}
+	return matching
+}
+
+// update checks a path received in an update.
+func (m *prefixMonitor) update(path *apiutil.Path) {
+	if m == nil || path.Nlri == nil {
+		return
+	}
+	prefix, err := netip.ParsePrefix(path.Nlri.String())
+	if err != nil {
+		return
+	}
+	key := indexPathKey{peer: path.PeerAddress.String(), prefix: path.Nlri.String(), id: path.RemoteID}
+
+	var (
+		info   *RouteInfo
+		alerts []EventType
+		events []Event
+	)
+	if !path.Withdrawal {
+		for _, expected := range m.matching(prefix) {
+			if info == nil {
+				routeInfo := m.router.routeInfoFromPath(key.prefix, path)
+				info = &routeInfo
+			}
+			origin := info.OriginAs
+			if len(info.AsPath) == 0 {
+				origin = m.router.Asn
+			}
+			covering := prefix.Bits() < expected.prefix.Bits()
+			if covering && prefix.Bits() == 0 {
+				// default routes cover everything
+				continue
+			}
+			if !slices.Contains(expected.Origins, origin) && !slices.Contains(alerts, EventUnexpectedOrigin) {
+				alerts = append(alerts, EventUnexpectedOrigin)
+				kind := "more specific of"
+				if prefix.Bits() == expected.prefix.Bits() {
+					kind = "path for"
+				} else if covering {
+					kind = "covering prefix of"
+				}
+				events = append(events, m.router.newEvent(EventUnexpectedOrigin, info,
+					fmt.Sprintf("%s %s received from %s is originated by AS%d, expected %s", kind, expected.Prefix, key.peer, origin, formatOrigins(expected.Origins))))
+			}
+			if !covering && prefix.Bits() > expected.MaxLength && !slices.Contains(alerts, EventMaxLengthExceeded) {
+				alerts = append(alerts, EventMaxLengthExceeded)
+				events = append(events, m.router.newEvent(EventMaxLengthExceeded, info,
+					fmt.Sprintf("more specific of %s received from %s exceeds its maximum length %d", expected.Prefix, key.peer, expected.MaxLength)))
+			}
+		}
+	}
+
+	m.lock.Lock()
+	previous := m.alerts[key]
+	if len(alerts) > 0 {
+		m.alerts[key] = alerts
+	} else {
+		delete(m.alerts, key)
+	}
+	events = slices.DeleteFunc(events, func(event Event) bool {
+		return slices.Contains(previous, event.Type)
+	})
+	for _, expected := range m.own {
+		if expected.prefix != prefix {
+			continue
+		}
+		if path.Withdrawal {
+			events = append(events, m.unsee(expected, key)...)
+		} else {
+			if m.seen[prefix] == nil {
+				m.seen[prefix] = make(map[indexPathKey]struct{})
+			}
+			m.seen[prefix][key] = struct{}{}
+			delete(m.missing, prefix)
+		}
+	}
+	m.lock.Unlock()
+
+	m.raise(events)
+}
+
+// dropPeer forgets all paths of a peer whose session went down.
+func (m *prefixMonitor) dropPeer(peer string) {
+	if m == nil {
+		return
+	}
+	var events []Event
+	m.lock.Lock()
+	for key := range m.alerts {
+		if key.peer == peer {
+			delete(m.alerts, key)
+		}
+	}
+	for _, expected := range m.own {
+		for key := range m.seen[expected.prefix] {
+			if key.peer == peer {
+				events = append(events, m.unsee(expected, key)...)
+			}
+		}
+	}
+	m.lock.Unlock()
+	m.raise(events)
+}
+
+// unsee removes a path of one of our own prefixes, returning an event if it
+// was the last one.
+func (m *prefixMonitor) unsee(expected *ExpectedPrefix, key indexPathKey) []Event {
+	paths := m.seen[expected.prefix]
+	if _, ok := paths[key]; !ok {
+		return nil
+	}
+	delete(paths, key)
+	if len(paths) > 0 {
+		return nil
+	}
+	delete(m.seen, expected.prefix)
+	m.missing[expected.prefix] = true
+	event := m.router.newEvent(EventPrefixMissing, nil, fmt.Sprintf("last path for %s was withdrawn by %s", expected.Prefix, key.peer))
+	event.Prefix = expected.Prefix
+	return []Event{event}
+}
+
+// endOfRib reports our own prefixes of the family for which no path was
+// received at all once a peer sent all of its paths.
+func (m *prefixMonitor) endOfRib(peer string, family bgp.Family) {
+	if m == nil {
+		return
+	}
+	var events []Event
+	m.lock.Lock()
+	for _, expected := range m.own {
+		if expected.prefix.Addr().Is4() != (family == bgp.RF_IPv4_UC) || len(m.seen[expected.prefix]) > 0 || m.missing[expected.prefix] {
+			continue
+		}
+		m.missing[expected.prefix] = true
+		event := m.router.newEvent(EventPrefixMissing, nil, fmt.Sprintf("no path for %s received until the end of RIB from %s", expected.Prefix, peer))
+		event.Prefix = expected.Prefix
+		events = append(events, event)
+	}
+	m.lock.Unlock()
+	m.raise(events)
+}
+
+func (m *prefixMonitor) raise(events []Event) {
+	for _, event := range events {
+		m.router.raise(event)
+	}
+}
+
+func formatOrigins(origins []uint32) string {
+	var s string
+	for i, origin := range origins {
+		if i > 0 {
+			s += " or "
+		}
+		s += fmt.Sprintf("AS%d", origin)
+	}
+	return s
+}
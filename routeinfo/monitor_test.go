@@ -0,0 +1,168 @@
+package routeinfo
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+)
+
+func TestPrefixMonitor(t *testing.T) {
+	server := RouteInfoServer{Asn: 64496}
+	own := &ExpectedPrefix{Prefix: "192.0.2.0/23", MaxLength: 24}
+	customer := &ExpectedPrefix{Prefix: "203.0.113.0/24", Origins: []uint32{64510}}
+	if err := own.init(server.Asn); err != nil {
+		t.Fatal(err)
+	}
+	if err := customer.init(0); err != nil {
+		t.Fatal(err)
+	}
+	router := &Router{Name: "test", Asn: 64496, Logger: &log.DefaultRouteInfoLogger{}, events: server.eventStream()}
+	monitor := newPrefixMonitor(router, []*ExpectedPrefix{own}, []*ExpectedPrefix{customer})
+	events, unsubscribe := server.Subscribe(10)
+	defer unsubscribe()
+
+	expect := func(want ...EventType) {
+		t.Helper()
+		for _, eventType := range want {
+			select {
+			case event := <-events:
+				if event.Type != eventType {
+					t.Errorf("got %s event (%s), want %s", event.Type, event.Message, eventType)
+				}
+			default:
+				t.Errorf("missing %s event", eventType)
+			}
+		}
+		select {
+		case event := <-events:
+			t.Errorf("unexpected %s event: %s", event.Type, event.Message)
+		default:
+		}
+	}
+	peer := netip.MustParseAddr("198.51.100.1")
+	update := func(route testRoute, withdrawal bool) {
+		path := newTestPath(t, route)
+		path.PeerAddress = peer
+		path.Withdrawal = withdrawal
+		monitor.update(path)
+	}
+
+	// expected announcements
+	update(testRoute{prefix: "192.0.2.0/23", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	update(testRoute{prefix: "192.0.3.0/24", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	update(testRoute{prefix: "203.0.113.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64510}}, false)
+	update(testRoute{prefix: "0.0.0.0/0", nexthop: "198.51.100.1", aspath: []uint32{64500}}, false)
+	update(testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500}}, false)
+	expect()
+
+	// more specifics
+	update(testRoute{prefix: "192.0.2.0/25", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	expect(EventMaxLengthExceeded)
+	update(testRoute{prefix: "192.0.2.0/25", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	expect()
+	update(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64666}}, false)
+	expect(EventUnexpectedOrigin)
+	update(testRoute{prefix: "203.0.113.128/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64666}}, false)
+	expect(EventUnexpectedOrigin, EventMaxLengthExceeded)
+
+	// covering prefix
+	update(testRoute{prefix: "203.0.112.0/23", nexthop: "198.51.100.1", aspath: []uint32{64500, 64666}}, false)
+	expect(EventUnexpectedOrigin)
+
+	// fixed and broken again
+	update(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	expect()
+	update(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64666}}, false)
+	expect(EventUnexpectedOrigin)
+
+	// disappearing own prefixes
+	update(testRoute{prefix: "192.0.2.0/23", nexthop: "198.51.100.1"}, true)
+	expect(EventPrefixMissing)
+	update(testRoute{prefix: "192.0.2.0/23", nexthop: "198.51.100.1"}, true)
+	expect()
+	update(testRoute{prefix: "192.0.2.0/23", nexthop: "198.51.100.1", aspath: []uint32{}}, false)
+	monitor.dropPeer(peer.String())
+	expect(EventPrefixMissing)
+}
+
+func TestPrefixMonitorNested(t *testing.T) {
+	server := RouteInfoServer{Asn: 64496}
+	own := &ExpectedPrefix{Prefix: "192.0.2.0/23", MaxLength: 24}
+	unseen := &ExpectedPrefix{Prefix: "198.51.100.0/24"}
+	customer := &ExpectedPrefix{Prefix: "192.0.2.0/24", Origins: []uint32{64510}}
+	for _, expected := range []*ExpectedPrefix{own, unseen, customer} {
+		if err := expected.init(server.Asn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	router := &Router{Name: "test", Asn: 64496, Logger: &log.DefaultRouteInfoLogger{}, events: server.eventStream()}
+	monitor := newPrefixMonitor(router, []*ExpectedPrefix{own, unseen}, []*ExpectedPrefix{customer})
+	events, unsubscribe := server.Subscribe(10)
+	defer unsubscribe()
+
+	expect := func(want ...EventType) {
+		t.Helper()
+		for _, eventType := range want {
+			select {
+			case event := <-events:
+				if event.Type != eventType {
+					t.Errorf("got %s event (%s), want %s", event.Type, event.Message, eventType)
+				}
+			default:
+				t.Errorf("missing %s event", eventType)
+			}
+		}
+		select {
+		case event := <-events:
+			t.Errorf("unexpected %s event: %s", event.Type, event.Message)
+		default:
+		}
+	}
+	update := func(route testRoute) {
+		path := newTestPath(t, route)
+		path.PeerAddress = netip.MustParseAddr("198.51.100.1")
+		monitor.update(path)
+	}
+
+	// the customer prefix is only checked against the customer's origins
+	update(testRoute{prefix: "192.0.2.0/23", nexthop: "198.51.100.1", aspath: []uint32{}})
+	update(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64510}})
+	update(testRoute{prefix: "192.0.3.0/24", nexthop: "198.51.100.1", aspath: []uint32{}})
+	expect()
+	update(testRoute{prefix: "192.0.2.0/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64510}})
+	expect(EventMaxLengthExceeded)
+	update(testRoute{prefix: "192.0.2.128/25", nexthop: "198.51.100.1", aspath: []uint32{}})
+	expect(EventUnexpectedOrigin, EventMaxLengthExceeded)
+	update(testRoute{prefix: "192.0.3.0/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64510}})
+	expect(EventUnexpectedOrigin, EventMaxLengthExceeded)
+
+	// covering both
+	update(testRoute{prefix: "192.0.0.0/22", nexthop: "198.51.100.1", aspath: []uint32{64500, 64666}})
+	expect(EventUnexpectedOrigin)
+
+	// own prefixes never received are reported once at the end of RIB
+	monitor.endOfRib("198.51.100.1", bgp.RF_IPv6_UC)
+	expect()
+	monitor.endOfRib("198.51.100.1", bgp.RF_IPv4_UC)
+	expect(EventPrefixMissing)
+	monitor.endOfRib("198.51.100.2", bgp.RF_IPv4_UC)
+	expect()
+	update(testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{}})
+	expect()
+}
+
+func TestExpectedPrefixInit(t *testing.T) {
+	for _, expected := range []ExpectedPrefix{
+		{Prefix: "192.0.2.0"},
+		{Prefix: "192.0.2.0/24", MaxLength: 23},
+		{Prefix: "192.0.2.0/24", MaxLength: 33},
+		{Prefix: "2001:db8::/32"},
+	} {
+		if err := expected.init(0); err == nil {
+			t.Errorf("invalid expectation %+v accepted", expected)
+		}
+	}
+}
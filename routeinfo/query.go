@@ -0,0 +1,466 @@
+package routeinfo
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Query is a compiled filter expression on paths, i.e.
+//
+//	origin_as = 3320 and community ~ "553:1*" and prefixlen >= 24 and rpki = invalid
+//
+// A query consists of comparisons "field operator value" joined with "and",
+// "or", "not" and parentheses. Values are numbers, words or double quoted
+// strings. The operators are =, !=, <, <=, >, >= for numeric fields and =, !=,
+// ~ (matches) and !~ (does not match) for all others. What matches means
+// depends on the field: AS paths match an AS path regular expression (see
+// ASPathRegexp), prefixes and addresses match if a prefix covers them, and
+// everything else matches a pattern where '*' stands for any number of
+// characters and '?' for a single one. Fields carrying multiple values like
+// communities match if any of their values does, and != or !~ only if none
+// does.
+//
+// The fields are prefix, prefixlen, family (ipv4 or ipv6), origin_as, aspath,
+// aspath_len, community, large_community, ext_community, nexthop, peer,
+// routerid, originatorid, localpref, med, origin (igp, egp or incomplete),
+// rpki (valid, invalid or not-found), aspa (valid, unknown or invalid) and
+// best (true or false).
+type Query struct {
+	expr string
+	root queryNode
+}
+
+type queryNode interface {
+	match(path *RouteInfo) bool
+}
+
+type queryAnd struct{ left, right queryNode }
+type queryOr struct{ left, right queryNode }
+type queryNot struct{ node queryNode }
+
+func (q queryAnd) match(path *RouteInfo) bool { return q.left.match(path) && q.right.match(path) }
+func (q queryOr) match(path *RouteInfo) bool  { return q.left.match(path) || q.right.match(path) }
+func (q queryNot) match(path *RouteInfo) bool { return !q.node.match(path) }
+
+type queryFieldKind int
+
+const (
+	fieldNumber queryFieldKind = iota
+	fieldString
+	fieldStrings
+	fieldPrefix
+	fieldAddress
+	fieldASPath
+	fieldBool
+)
+
+type queryField struct {
+	kind    queryFieldKind
+	number  func(path *RouteInfo) uint64
+	str     func(path *RouteInfo) string
+	strs    func(path *RouteInfo) []string
+	boolean func(path *RouteInfo) bool
+}
+
+var queryFields = map[string]queryField{
+	"prefix": {kind: fieldPrefix, str: func(path *RouteInfo) string { return path.Prefix }},
+	"prefixlen": {kind: fieldNumber, number: func(path *RouteInfo) uint64 {
+		if prefix, err := netip.ParsePrefix(path.Prefix); err == nil {
+			return uint64(prefix.Bits())
+		}
+		return 0
+	}},
+	"family": {kind: fieldString, str: func(path *RouteInfo) string {
+		if isIPv6Prefix(path.Prefix) {
+			return "ipv6"
+		}
+		return "ipv4"
+	}},
+	"origin_as":       {kind: fieldNumber, number: func(path *RouteInfo) uint64 { return uint64(path.OriginAs) }},
+	"aspath":          {kind: fieldASPath},
+	"aspath_len":      {kind: fieldNumber, number: func(path *RouteInfo) uint64 { return uint64(len(path.AsPath)) }},
+	"community":       {kind: fieldStrings, strs: func(path *RouteInfo) []string { return path.Communities }},
+	"large_community": {kind: fieldStrings, strs: func(path *RouteInfo) []string { return path.LargeCommunities }},
+	"ext_community":   {kind: fieldStrings, strs: func(path *RouteInfo) []string { return path.ExtendedCommunities }},
+	"nexthop":         {kind: fieldAddress, str: func(path *RouteInfo) string { return path.NextHop }},
+	"peer":            {kind: fieldAddress, str: func(path *RouteInfo) string { return path.Peer }},
+	"routerid":        {kind: fieldAddress, str: func(path *RouteInfo) string { return path.RouterId }},
+	"originatorid":    {kind: fieldAddress, str: func(path *RouteInfo) string { return path.OriginatorId }},
+	"localpref":       {kind: fieldNumber, number: func(path *RouteInfo) uint64 { return uint64(path.LocalPref) }},
+	"med":             {kind: fieldNumber, number: func(path *RouteInfo) uint64 { return uint64(path.Med) }},
+	"origin":          {kind: fieldString, str: func(path *RouteInfo) string { return path.Origin.String() }},
+	"rpki":            {kind: fieldString, str: func(path *RouteInfo) string { return path.Validation.String() }},
+	"aspa": {kind: fieldString, str: func(path *RouteInfo) string {
+		if path.ASPA == nil {
+			return ""
+		}
+		return path.ASPA.State.String()
+	}},
+	"best": {kind: fieldBool, boolean: func(path *RouteInfo) bool { return path.Best }},
+}
+
+// queryComparison is a single comparison, with the value parsed according to
+// the kind of the field.
+type queryComparison struct {
+	field   queryField
+	op      string
+	number  uint64
+	str     string
+	pattern *regexp.Regexp
+	covered bool // prefixes and addresses are matched against a covering prefix
+	prefix  netip.Prefix
+	addr    netip.Addr
+	aspath  *ASPathRegexp
+	boolean bool
+}
+
+func (c *queryComparison) match(path *RouteInfo) bool {
+	negate := c.op == "!=" || c.op == "!~"
+	var match bool
+	switch c.field.kind {
+	case fieldNumber:
+		value := c.field.number(path)
+		switch c.op {
+		case "=":
+			return value == c.number
+		case "!=":
+			return value != c.number
+		case "<":
+			return value < c.number
+		case "<=":
+			return value <= c.number
+		case ">":
+			return value > c.number
+		case ">=":
+			return value >= c.number
+		}
+	case fieldString:
+		match = c.matchString(c.field.str(path))
+	case fieldStrings:
+		match = slices.ContainsFunc(c.field.strs(path), c.matchString)
+	case fieldPrefix:
+		prefix, err := netip.ParsePrefix(c.field.str(path))
+		if err != nil {
+			match = false
+		} else if !c.covered {
+			match = prefix.Masked() == c.prefix
+		} else {
+			match = prefix.Bits() >= c.prefix.Bits() && c.prefix.Contains(prefix.Addr())
+		}
+	case fieldAddress:
+		addr, err := netip.ParseAddr(c.field.str(path))
+		if err != nil {
+			match = false
+		} else if !c.covered {
+			match = addr.Unmap() == c.addr
+		} else {
+			match = c.prefix.Contains(addr.Unmap())
+		}
+	case fieldASPath:
+		if c.aspath != nil {
+			match = c.aspath.MatchASPath(path.AsPath)
+		} else {
+			match = formatASPath(path.AsPath) == c.str
+		}
+	case fieldBool:
+		match = c.field.boolean(path) == c.boolean
+	}
+	return match != negate
+}
+
+func (c *queryComparison) matchString(value string) bool {
+	if c.pattern != nil {
+		return c.pattern.MatchString(value)
+	}
+	return strings.EqualFold(value, c.str)
+}
+
+// globRegexp translates a pattern with '*' and '?' wildcards to a case
+// insensitive regular expression.
+func globRegexp(glob string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(glob)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("(?i)^" + quoted + "$")
+}
+
+func newQueryComparison(name string, op string, value string) (*queryComparison, error) {
+	field, ok := queryFields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s", name)
+	}
+	c := &queryComparison{field: field, op: op, str: value}
+	matching := op == "~" || op == "!~"
+	if field.kind != fieldNumber && op != "=" && op != "!=" && !matching {
+		return nil, fmt.Errorf("operator %s is not supported for field %s", op, name)
+	}
+	if field.kind == fieldNumber && matching {
+		return nil, fmt.Errorf("operator %s is not supported for field %s", op, name)
+	}
+
+	var err error
+	switch field.kind {
+	case fieldNumber:
+		c.number, err = strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s for field %s", value, name)
+		}
+	case fieldString, fieldStrings:
+		if matching {
+			c.pattern = globRegexp(value)
+		}
+	case fieldPrefix:
+		c.prefix, err = netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix %s for field %s", value, name)
+		}
+		c.prefix = c.prefix.Masked()
+		c.covered = matching
+	case fieldAddress:
+		if matching {
+			c.prefix, err = netip.ParsePrefix(value)
+			c.prefix = c.prefix.Masked()
+			c.covered = true
+		} else {
+			c.addr, err = netip.ParseAddr(value)
+			c.addr = c.addr.Unmap()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s for field %s", value, name)
+		}
+	case fieldASPath:
+		if matching {
+			c.aspath, err = CompileASPathRegexp(value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case fieldBool:
+		c.boolean, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %s for field %s", value, name)
+		}
+	}
+	return c, nil
+}
+
+type queryToken struct {
+	kind  byte // 'w' for words, 's' for quoted strings, 'o' for operators, '(' and ')'
+	value string
+	pos   int
+}
+
+func tokenizeQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(expr); {
+		switch ch := expr[i]; {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '(' || ch == ')':
+			tokens = append(tokens, queryToken{kind: ch, value: string(ch), pos: i})
+			i++
+		case ch == '"':
+			var value strings.Builder
+			start := i
+			i++
+			for ; i < len(expr) && expr[i] != '"'; i++ {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					i++
+				}
+				value.WriteByte(expr[i])
+			}
+			if i == len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, queryToken{kind: 's', value: value.String(), pos: start})
+			i++
+		case strings.IndexByte("=!<>~", ch) >= 0:
+			op := string(ch)
+			if i+1 < len(expr) && (expr[i+1] == '=' || ch == '!' && expr[i+1] == '~') {
+				op += string(expr[i+1])
+			}
+			token := queryToken{kind: 'o', value: op, pos: i}
+			switch op {
+			case "==":
+				token.value = "="
+			case "=", "!=", "<", "<=", ">", ">=", "~", "!~":
+			default:
+				return nil, fmt.Errorf("invalid operator %s at position %d", op, i)
+			}
+			tokens = append(tokens, token)
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && strings.IndexByte(" \t\n\r()\"=!<>~", expr[i]) < 0 {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: 'w', value: expr[start:i], pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+	end    int
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *queryParser) keyword(keyword string) bool {
+	token := p.peek()
+	if token != nil && token.kind == 'w' && strings.EqualFold(token.value, keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	pos := p.end
+	if token := p.peek(); token != nil {
+		pos = token.pos
+	}
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), pos)
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.keyword("not") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{node}, nil
+	}
+	token := p.peek()
+	if token == nil {
+		return nil, p.errorf("expected comparison")
+	}
+	if token.kind == '(' {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := p.peek(); token == nil || token.kind != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryNode, error) {
+	field := p.peek()
+	if field == nil || field.kind != 'w' {
+		return nil, p.errorf("expected field")
+	}
+	p.pos++
+	op := p.peek()
+	if op == nil || op.kind != 'o' {
+		return nil, p.errorf("expected operator")
+	}
+	p.pos++
+	value := p.peek()
+	if value == nil || (value.kind != 'w' && value.kind != 's') {
+		return nil, p.errorf("expected value")
+	}
+	p.pos++
+	c, err := newQueryComparison(strings.ToLower(field.value), op.value, value.value)
+	if err != nil {
+		return nil, fmt.Errorf("%w at position %d", err, field.pos)
+	}
+	return c, nil
+}
+
+// CompileQuery parses a filter expression, see Query for the syntax.
+func CompileQuery(expr string) (*Query, error) {
+	tokens, err := tokenizeQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	parser := &queryParser{tokens: tokens, end: len(expr)}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.peek() != nil {
+		return nil, parser.errorf("unexpected %s", parser.peek().value)
+	}
+	return &Query{expr: expr, root: root}, nil
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Match reports whether the path matches the query.
+func (q *Query) Match(path *RouteInfo) bool {
+	return q.root.match(path)
+}
+
+// Query calls fn with each path in the RIB matching the filter expression, see
+// Query for the syntax, until fn returns false. Paths are passed as they are
+// found in no particular order, so results of queries matching large parts of
+// the RIB need not be kept in memory.
+func (r *Router) Query(expr string, fn func(path RouteInfo) bool) error {
+	query, err := CompileQuery(expr)
+	if err != nil {
+		return err
+	}
+	r.WalkQuery(query, fn)
+	return nil
+}
+
+// WalkQuery is Query for a compiled query, so it can be run on several routers.
+func (r *Router) WalkQuery(query *Query, fn func(path RouteInfo) bool) {
+	r.WalkUntil(func(prefix string, paths []RouteInfo) bool {
+		for _, path := range paths {
+			if query.Match(&path) && !fn(path) {
+				return false
+			}
+		}
+		return true
+	})
+}
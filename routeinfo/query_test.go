@@ -0,0 +1,112 @@
+package routeinfo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestQuery(t *testing.T) {
+	path := RouteInfo{
+		AsPath:           []uint32{174, 3320, 553},
+		Best:             true,
+		Communities:      []string{"553:123", "3320:1"},
+		LargeCommunities: []string{"553:0:1"},
+		LocalPref:        100,
+		NextHop:          "192.0.2.1",
+		OriginAs:         553,
+		Origin:           IGP,
+		Peer:             "192.0.2.254",
+		Prefix:           "198.51.100.0/24",
+		Validation:       bgp.VALIDATION_STATE_INVALID,
+	}
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{`origin_as = 553 and community ~ "553:1*" and prefixlen >= 24 and rpki = invalid`, true},
+		{`origin_as = AS553`, true},
+		{`origin_as != 553`, false},
+		{`prefixlen > 24`, false},
+		{`community = 553:123`, true},
+		{`community != 553:123`, false},
+		{`community !~ "65535:*"`, true},
+		{`large_community ~ "553:?:1"`, true},
+		{`aspath ~ "_3320_"`, true},
+		{`aspath = "174 3320 553"`, true},
+		{`aspath_len = 3`, true},
+		{`prefix = 198.51.100.0/24`, true},
+		{`prefix ~ 198.51.0.0/16`, true},
+		{`prefix ~ 198.51.100.0/25`, false},
+		{`nexthop = 192.0.2.1`, true},
+		{`nexthop ~ 192.0.2.0/24 and peer != 192.0.2.1`, true},
+		{`family = ipv6 or origin = IGP`, true},
+		{`not (best = true or localpref < 100)`, false},
+		{`NOT best == false AND (med = 1 OR med = 0)`, true},
+		{`aspa = valid`, false},
+		{`aspa != valid`, true},
+	}
+	for _, test := range tests {
+		query, err := CompileQuery(test.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", test.expr, err)
+		}
+		if match := query.Match(&path); match != test.match {
+			t.Errorf("%s: got %t, want %t", test.expr, match, test.match)
+		}
+	}
+
+	for _, expr := range []string{
+		``,
+		`origin_as`,
+		`origin_as =`,
+		`origin_as ~ 553`,
+		`prefixlen = x`,
+		`unknown = 1`,
+		`community < 553:1`,
+		`prefix = 198.51.100.0`,
+		`best = maybe`,
+		`aspath ~ "(174"`,
+		`(best = true`,
+		`best = true)`,
+		`best = true best = false`,
+		`community = "553:1`,
+		`origin_as => 553`,
+	} {
+		if _, err := CompileQuery(expr); err == nil {
+			t.Errorf("invalid query %q compiled", expr)
+		}
+	}
+}
+
+func TestRouterQuery(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100, communities: []uint32{64500<<16 | 1}},
+		testRoute{prefix: "198.51.100.0/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500, 64501}, localPref: 100},
+	)
+	var prefixes []string
+	err := router.Query(`origin_as = 64501 and (community ~ "64500:*" or prefixlen = 25)`, func(path RouteInfo) bool {
+		prefixes = append(prefixes, path.Prefix)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(prefixes)
+	if len(prefixes) != 2 || prefixes[0] != "192.0.2.0/24" || prefixes[1] != "198.51.100.0/25" {
+		t.Errorf("unexpected prefixes %v", prefixes)
+	}
+	count := 0
+	err = router.Query(`origin_as = 64501`, func(RouteInfo) bool {
+		count++
+		return false
+	})
+	if err != nil || count != 1 {
+		t.Errorf("query did not stop after the first path, got %d paths", count)
+	}
+	if err := router.Query(`origin_as ~ 64501`, func(RouteInfo) bool { return true }); err == nil {
+		t.Error("invalid query did not fail")
+	}
+}
@@ -0,0 +1,85 @@
+package routeinfo
+
+import (
+	"strings"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+var unicastFamilies = []bgp.Family{bgp.RF_IPv4_UC, bgp.RF_IPv6_UC}
+
+// Walk calls fn with all paths of each prefix in the RIB of the router, IPv4
+// prefixes first. The RIB is copied per family beforehand, so fn may take its
+// time.
+func (r *Router) Walk(fn func(prefix string, paths []RouteInfo)) {
+	r.WalkUntil(func(prefix string, paths []RouteInfo) bool {
+		fn(prefix, paths)
+		return true
+	})
+}
+
+// WalkUntil is Walk stopping once fn returns false.
+func (r *Router) WalkUntil(fn func(prefix string, paths []RouteInfo) bool) {
+	for _, family := range unicastFamilies {
+		stopped := false
+		err := r.GobgpServer.ListPath(apiutil.ListPathRequest{
+			TableType: api.TableType_TABLE_TYPE_GLOBAL,
+			Family:    family,
+		}, func(prefix bgp.NLRI, paths []*apiutil.Path) {
+			// the listing can't be aborted, but the remaining paths are
+			// not converted anymore
+			if stopped {
+				return
+			}
+			pre := prefix.String()
+			results := make([]RouteInfo, 0, len(paths))
+			for _, path := range paths {
+				results = append(results, r.routeInfoFromPath(pre, path))
+			}
+			stopped = !fn(pre, results)
+		})
+		if err != nil {
+			r.Logger.GetApplicationLogger().Errorf("Failed listing %s paths of router %s due to %v", family, r.Name, err)
+		}
+		if stopped {
+			return
+		}
+	}
+}
+
+// OriginPrefixes are the prefixes originated by an AS and all their paths.
+type OriginPrefixes struct {
+	Asn   uint32      `json:"asn"`
+	IPv4  int         `json:"ipv4"`
+	IPv6  int         `json:"ipv6"`
+	Paths []RouteInfo `json:"paths"`
+}
+
+// PrefixesByOriginAS returns all prefixes with at least one path originated by
+// the given AS, and all paths originated by it.
+func (r *Router) PrefixesByOriginAS(asn uint32) OriginPrefixes {
+	result := OriginPrefixes{Asn: asn}
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		found := false
+		for _, path := range paths {
+			if len(path.AsPath) > 0 && path.OriginAs == asn {
+				result.Paths = append(result.Paths, path)
+				found = true
+			}
+		}
+		if found {
+			if isIPv6Prefix(prefix) {
+				result.IPv6++
+			} else {
+				result.IPv4++
+			}
+		}
+	})
+	return result
+}
+
+func isIPv6Prefix(prefix string) bool {
+	return strings.Contains(prefix, ":")
+}
@@ -0,0 +1,136 @@
+package routeinfo
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/server"
+	"sigs.k8s.io/yaml"
+)
+
+type testRoute struct {
+	prefix      string
+	nexthop     string
+	aspath      []uint32
+	localPref   uint32
+	communities []uint32
+}
+
+// newTestRouter returns a router without neighbors whose RIB contains the
+// given routes as locally originated paths. Updates are not watched, so the
+// routes are added to the community index directly.
+func newTestRouter(t *testing.T, routes ...testRoute) *Router {
+	t.Helper()
+	logger := &log.DefaultRouteInfoLogger{}
+	logger.DisableBgpLog()
+	bgpServer := server.NewBgpServer(server.LoggerOption(logger.GetBgpLogger()))
+	go bgpServer.Serve()
+	t.Cleanup(bgpServer.Stop)
+	if err := bgpServer.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{Asn: 64496, RouterId: "192.0.2.255", ListenPort: -1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	index := newCommunityIndex()
+	for _, route := range routes {
+		path := newTestPath(t, route)
+		if _, err := bgpServer.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{path}}); err != nil {
+			t.Fatal(err)
+		}
+		index.update(path)
+	}
+
+	return &Router{
+		Name:           "test",
+		Asn:            64496,
+		GobgpServer:    bgpServer,
+		Logger:         logger,
+		communities:    NewCommunityDictionary(nil),
+		communityIndex: index,
+	}
+}
+
+// newTestPath returns a locally originated path for the route.
+func newTestPath(t *testing.T, route testRoute) *apiutil.Path {
+	t.Helper()
+	prefix := netip.MustParsePrefix(route.prefix)
+	nexthop := netip.MustParseAddr(route.nexthop)
+	nlri, err := bgp.NewIPAddrPrefix(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	family := bgp.RF_IPv4_UC
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, route.aspath),
+		}),
+		bgp.NewPathAttributeLocalPref(route.localPref),
+	}
+	if len(route.communities) > 0 {
+		attrs = append(attrs, bgp.NewPathAttributeCommunities(route.communities))
+	}
+	if prefix.Addr().Is4() {
+		nh, err := bgp.NewPathAttributeNextHop(nexthop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		attrs = append(attrs, nh)
+	} else {
+		family = bgp.RF_IPv6_UC
+		mpReach, err := bgp.NewPathAttributeMpReachNLRI(family, []bgp.PathNLRI{{NLRI: nlri}}, nexthop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		attrs = append(attrs, mpReach)
+	}
+	return &apiutil.Path{
+		Family: family,
+		Nlri:   nlri,
+		Attrs:  attrs,
+		Age:    time.Now().Unix(),
+	}
+}
+
+// newTestServer returns an initialized server configured by the YAML. The
+// neighbors are never reached, so the routers stay without paths.
+func newTestServer(t *testing.T, config string) *RouteInfoServer {
+	t.Helper()
+	routeInfo := &RouteInfoServer{}
+	if err := yaml.Unmarshal([]byte(config), routeInfo); err != nil {
+		t.Fatal(err)
+	}
+	routeInfo.InitLogger(nil)
+	routeInfo.Logger.DisableBgpLog()
+	routeInfo.Init()
+	t.Cleanup(routeInfo.Stop)
+	return routeInfo
+}
+
+func TestPrefixesByOriginAS(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64502}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "203.0.113.0/24", nexthop: "198.51.100.1", aspath: []uint32{64501, 64502}, localPref: 100},
+	)
+
+	result := router.PrefixesByOriginAS(64501)
+	if result.IPv4 != 1 || result.IPv6 != 1 || len(result.Paths) != 2 {
+		t.Fatalf("got %d IPv4 and %d IPv6 prefixes with %d paths", result.IPv4, result.IPv6, len(result.Paths))
+	}
+	if result.Paths[0].Prefix != "192.0.2.0/24" || result.Paths[1].NextHop != "2001:db8:ffff::1" {
+		t.Errorf("unexpected paths %+v", result.Paths)
+	}
+
+	if result := router.PrefixesByOriginAS(64500); len(result.Paths) != 0 {
+		t.Errorf("transit AS returned as origin: %+v", result.Paths)
+	}
+}
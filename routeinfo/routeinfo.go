@@ -6,9 +6,11 @@ import (
 	"net"
 	"net/netip"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/BelWue/bgp_routeinfo/asnames"
 	"github.com/BelWue/bgp_routeinfo/log"
 	"github.com/osrg/gobgp/v4/api"
 	"github.com/osrg/gobgp/v4/pkg/apiutil"
@@ -18,10 +20,26 @@ import (
 )
 
 type RouteInfoServer struct {
-	Asn      uint32             `yaml:"asn"`
-	RouterId string             `yaml:"routerid"`
-	Routers  map[string]*Router `yaml:"routers"`
-	Logger   log.RouteinfoLogger
+	Asn           uint32             `yaml:"asn"`
+	RouterId      string             `yaml:"routerid"`
+	Routers       map[string]*Router `yaml:"routers"`
+	CommunityFile string             `yaml:"communityfile"`
+	RPKI          *RPKIValidator     `yaml:"rpki"`
+	Bogons        *BogonDetector     `yaml:"bogons"`
+	ASNames       *asnames.Registry  `yaml:"asnames"`
+	IRR           *IRRValidator      `yaml:"irr"`
+	// prefixes whose origin and length are monitored, see ExpectedPrefix
+	OwnPrefixes      []*ExpectedPrefix `yaml:"ownprefixes"`
+	CustomerPrefixes []*ExpectedPrefix `yaml:"customerprefixes"`
+	// prefixes whose best paths are watched for changes
+	WatchPrefixes []string   `yaml:"watchprefixes"`
+	Webhooks      []*Webhook `yaml:"webhooks"`
+	Communities   *CommunityDictionary
+	Logger        log.RouteinfoLogger
+	events        *eventStream
+	eventsOnce    sync.Once
+	unsubscribe   []func()
+	snapshots     snapshotStore
 }
 
 func (rs *RouteInfoServer) InitLogger(logLevel *string) {
@@ -64,27 +82,43 @@ func (rs *RouteInfoServer) getBgpInstance(router *Router) *server.BgpServer {
 			return
 		}
 		router.neighborSessionStateLock.Lock()
+		previous := router.neighborSessionState[peer.NeighborAddress.String()]
 		router.neighborSessionState[peer.NeighborAddress.String()] = peer.SessionState
 		router.neighborSessionStateLock.Unlock()
+		if previous == bgp.BGP_FSM_ESTABLISHED && peer.SessionState != bgp.BGP_FSM_ESTABLISHED {
+			router.raise(router.newEvent(EventNeighborDown, nil, fmt.Sprintf("Peer %d/%s left the established state, now '%s'", peer.PeerASN, peer.NeighborAddress, peer.SessionState)))
+		}
 		if peer.SessionState == bgp.BGP_FSM_ESTABLISHED {
 			rs.Logger.GetApplicationLogger().Infof("Peer %d/%s is in FSM state '%s' (admin state = '%s')", peer.PeerASN, peer.NeighborAddress, peer.SessionState, peer.AdminState.String())
 		} else {
+			router.communityIndex.dropPeer(peer.NeighborAddress.String())
+			router.monitor.dropPeer(peer.NeighborAddress.String())
 			rs.Logger.GetApplicationLogger().Debugf("Peer %d/%s is in FSM state '%s' (admin state = '%s')", peer.PeerASN, peer.NeighborAddress, peer.SessionState, peer.AdminState.String())
 		}
 	}
 
 	callbacks.OnPathUpdate = func(p []*apiutil.Path, t time.Time) {
-		rs.Logger.GetApplicationLogger().Infof("OnPathUpdate: %v", p)
+		rs.Logger.GetApplicationLogger().Debugf("OnPathUpdate: %v", p)
+		for _, path := range p {
+			router.communityIndex.update(path)
+			router.countBogons(path)
+			router.monitor.update(path)
+		}
 	}
 	callbacks.OnBestPath = func(p []*apiutil.Path, t time.Time) {
-		rs.Logger.GetApplicationLogger().Infof("OnBestPath: %v", p)
+		rs.Logger.GetApplicationLogger().Debugf("OnBestPath: %v", p)
+		router.bestPaths.update(p)
 	}
 	callbacks.OnPathEor = func(p *apiutil.Path, t time.Time) {
 		rs.Logger.GetApplicationLogger().Infof("OnPathEor: %v", p)
+		router.monitor.endOfRib(p.PeerAddress.String(), p.Family)
 	}
 
-	watchOption := server.WatchPeer()
-	err := bgpServer.WatchEvent(context.Background(), callbacks, watchOption)
+	watchOptions := []server.WatchOption{server.WatchPeer(), server.WatchUpdate(true, "", "")}
+	if router.bestPaths != nil {
+		watchOptions = append(watchOptions, server.WatchBestPath(false))
+	}
+	err := bgpServer.WatchEvent(context.Background(), callbacks, watchOptions...)
 	if err != nil {
 		rs.Logger.GetApplicationLogger().Errorf("Failed to create bgp session %v", err)
 	}
@@ -93,14 +127,94 @@ func (rs *RouteInfoServer) getBgpInstance(router *Router) *server.BgpServer {
 }
 
 func (rs *RouteInfoServer) Init() {
+	if rs.Communities == nil {
+		if rs.CommunityFile != "" {
+			communities, err := LoadCommunityDictionary(rs.CommunityFile)
+			if err != nil {
+				rs.Logger.GetApplicationLogger().Fatalf("Failed to load community file: %v", err)
+			}
+			rs.Communities = communities
+		} else {
+			rs.Communities = NewCommunityDictionary(nil)
+		}
+	}
+	if rs.RPKI != nil {
+		rs.RPKI.Logger = rs.Logger
+		if err := rs.RPKI.Start(); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Failed to start RPKI validation: %v", err)
+		}
+	}
+	for _, expected := range rs.OwnPrefixes {
+		if err := expected.init(rs.Asn); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Invalid own prefix: %v", err)
+		}
+	}
+	for _, expected := range rs.CustomerPrefixes {
+		if err := expected.init(0); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Invalid customer prefix: %v", err)
+		}
+	}
+	if rs.Bogons == nil {
+		rs.Bogons = &BogonDetector{}
+	}
+	rs.Bogons.Logger = rs.Logger
+	if err := rs.Bogons.Start(); err != nil {
+		rs.Logger.GetApplicationLogger().Fatalf("Failed to load bogons: %v", err)
+	}
+	if rs.ASNames != nil {
+		rs.ASNames.Logger = rs.Logger
+		if err := rs.ASNames.Start(); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Failed to load AS names: %v", err)
+		}
+	}
+	if rs.IRR != nil {
+		rs.IRR.Logger = rs.Logger
+		if err := rs.IRR.Start(); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Failed to load IRR data: %v", err)
+		}
+	}
+	var watchPrefixes []netip.Prefix
+	for _, p := range rs.WatchPrefixes {
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Invalid watched prefix: %v", err)
+		}
+		watchPrefixes = append(watchPrefixes, prefix)
+	}
+	for _, webhook := range rs.Webhooks {
+		if err := webhook.init(); err != nil {
+			rs.Logger.GetApplicationLogger().Fatalf("Invalid webhook: %v", err)
+		}
+		events, unsubscribe := rs.Subscribe(100)
+		rs.unsubscribe = append(rs.unsubscribe, unsubscribe)
+		go webhook.run(events, rs.Logger)
+	}
 	for name, router := range rs.Routers {
+		if router.Name == "" {
+			router.Name = name
+		}
 		router.Logger = rs.Logger
+		router.events = rs.eventStream()
+		router.communities = rs.Communities
+		router.rpki = rs.RPKI
+		router.bogons = rs.Bogons
+		router.asnames = rs.ASNames
+		router.irr = rs.IRR
+		if router.monitor == nil && len(rs.OwnPrefixes)+len(rs.CustomerPrefixes) > 0 {
+			router.monitor = newPrefixMonitor(router, rs.OwnPrefixes, rs.CustomerPrefixes)
+		}
+		if router.bestPaths == nil && len(watchPrefixes) > 0 {
+			router.bestPaths = newBestPathWatcher(router, watchPrefixes)
+		}
 		if len(router.Neighbors) == 0 {
 			rs.Logger.GetApplicationLogger().Fatalf("unconfigured router %s\n", name)
 		}
 		if router.Asn == 0 {
 			router.Asn = rs.Asn
 		}
+		if router.communityIndex == nil {
+			router.communityIndex = newCommunityIndex()
+		}
 		if router.GobgpServer == nil {
 			router.GobgpServer = rs.getBgpInstance(router)
 		}
@@ -112,6 +226,21 @@ func (rs *RouteInfoServer) Init() {
 }
 
 func (rs *RouteInfoServer) Stop() {
+	for _, unsubscribe := range rs.unsubscribe {
+		unsubscribe()
+	}
+	if rs.RPKI != nil {
+		rs.RPKI.Stop()
+	}
+	if rs.Bogons != nil {
+		rs.Bogons.Stop()
+	}
+	if rs.ASNames != nil {
+		rs.ASNames.Stop()
+	}
+	if rs.IRR != nil {
+		rs.IRR.Stop()
+	}
 	var wg sync.WaitGroup
 	for _, router := range rs.Routers {
 		wg.Add(1)
@@ -131,6 +260,15 @@ type Router struct {
 	neighborSessionStateLock sync.Mutex
 	GobgpServer              *server.BgpServer
 	Logger                   log.RouteinfoLogger
+	communities              *CommunityDictionary
+	rpki                     *RPKIValidator
+	bogons                   *BogonDetector
+	asnames                  *asnames.Registry
+	irr                      *IRRValidator
+	communityIndex           *communityIndex
+	monitor                  *prefixMonitor
+	bestPaths                *bestPathWatcher
+	events                   *eventStream
 }
 
 func (r *Router) Connect() {
@@ -287,132 +425,196 @@ func (r *Router) lookup(address string, lookupType apiutil.LookupOption) []Route
 	// generate a result per path returned
 	var results []RouteInfo
 	for _, path := range *pa {
-		var (
-			nexthop             *netip.Addr
-			mpReach             *bgp.PathAttributeMpReachNLRI
-			asPath              *[]bgp.AsPathParamInterface
-			communities         *[]uint32
-			origin              *uint8
-			multiExitDisc       *uint32
-			localPref           *uint32
-			largeCommunities    *[]*bgp.LargeCommunity
-			extendedCommunities *[]bgp.ExtendedCommunityInterface
-			aspathNbrs          []uint32
-			communityNames      []string
-			largecommunityNames []string
-
-			nexthopString string
-		)
-
-		for _, a := range path.Attrs {
-			switch a.GetType() {
-			case bgp.BGP_ATTR_TYPE_NEXT_HOP:
-				nexthop = &a.(*bgp.PathAttributeNextHop).Value
-			case bgp.BGP_ATTR_TYPE_MP_REACH_NLRI:
-				mpReach = a.(*bgp.PathAttributeMpReachNLRI)
-			case bgp.BGP_ATTR_TYPE_AS_PATH:
-				asPath = &a.(*bgp.PathAttributeAsPath).Value
-			case bgp.BGP_ATTR_TYPE_COMMUNITIES:
-				communities = &a.(*bgp.PathAttributeCommunities).Value
-			case bgp.BGP_ATTR_TYPE_ORIGIN:
-				origin = &a.(*bgp.PathAttributeOrigin).Value
-			case bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC:
-				multiExitDisc = &a.(*bgp.PathAttributeMultiExitDisc).Value
-			case bgp.BGP_ATTR_TYPE_LOCAL_PREF:
-				localPref = &a.(*bgp.PathAttributeLocalPref).Value
-			case bgp.BGP_ATTR_TYPE_LARGE_COMMUNITY:
-				largeCommunities = &a.(*bgp.PathAttributeLargeCommunities).Values
-			case bgp.BGP_ATTR_TYPE_EXTENDED_COMMUNITIES:
-				extendedCommunities = &a.(*bgp.PathAttributeExtendedCommunities).Value
-			}
-		}
+		results = append(results, r.routeInfoFromPath(pre, path))
+	}
+	return results
+}
 
-		if nexthop != nil {
-			nexthopString = nexthop.String()
-		} else if mpReach != nil {
-			nexthopString = mpReach.Nexthop.String()
-		} else {
-			nexthopString = "N/A"
+func (r *Router) routeInfoFromPath(pre string, path *apiutil.Path) RouteInfo {
+	var (
+		nexthop                *netip.Addr
+		mpReach                *bgp.PathAttributeMpReachNLRI
+		asPath                 *[]bgp.AsPathParamInterface
+		communities            *[]uint32
+		origin                 *uint8
+		multiExitDisc          *uint32
+		localPref              *uint32
+		largeCommunities       *[]*bgp.LargeCommunity
+		extendedCommunities    *[]bgp.ExtendedCommunityInterface
+		originatorId           *netip.Addr
+		clusterList            *[]netip.Addr
+		aspathNbrs             []uint32
+		communityNames         []string
+		largecommunityNames    []string
+		extendedCommunityNames []string
+
+		nexthopString string
+	)
+
+	for _, a := range path.Attrs {
+		switch a.GetType() {
+		case bgp.BGP_ATTR_TYPE_NEXT_HOP:
+			nexthop = &a.(*bgp.PathAttributeNextHop).Value
+		case bgp.BGP_ATTR_TYPE_MP_REACH_NLRI:
+			mpReach = a.(*bgp.PathAttributeMpReachNLRI)
+		case bgp.BGP_ATTR_TYPE_AS_PATH:
+			asPath = &a.(*bgp.PathAttributeAsPath).Value
+		case bgp.BGP_ATTR_TYPE_COMMUNITIES:
+			communities = &a.(*bgp.PathAttributeCommunities).Value
+		case bgp.BGP_ATTR_TYPE_ORIGIN:
+			origin = &a.(*bgp.PathAttributeOrigin).Value
+		case bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC:
+			multiExitDisc = &a.(*bgp.PathAttributeMultiExitDisc).Value
+		case bgp.BGP_ATTR_TYPE_LOCAL_PREF:
+			localPref = &a.(*bgp.PathAttributeLocalPref).Value
+		case bgp.BGP_ATTR_TYPE_LARGE_COMMUNITY:
+			largeCommunities = &a.(*bgp.PathAttributeLargeCommunities).Values
+		case bgp.BGP_ATTR_TYPE_EXTENDED_COMMUNITIES:
+			extendedCommunities = &a.(*bgp.PathAttributeExtendedCommunities).Value
+		case bgp.BGP_ATTR_TYPE_ORIGINATOR_ID:
+			originatorId = &a.(*bgp.PathAttributeOriginatorId).Value
+		case bgp.BGP_ATTR_TYPE_CLUSTER_LIST:
+			clusterList = &a.(*bgp.PathAttributeClusterList).Value
 		}
+	}
 
-		// decode aspath
-		if asPath != nil {
-			for _, segment := range *asPath {
-				aspathNbrs = append(aspathNbrs, segment.GetAS()...)
-			}
+	if nexthop != nil {
+		nexthopString = nexthop.String()
+	} else if mpReach != nil {
+		nexthopString = mpReach.Nexthop.String()
+	} else {
+		nexthopString = "N/A"
+	}
+
+	// decode aspath
+	if asPath != nil {
+		for _, segment := range *asPath {
+			aspathNbrs = append(aspathNbrs, segment.GetAS()...)
 		}
+	}
 
-		// decode communities
-		if communities != nil {
-			for _, community := range *communities {
-				front := community >> 16
-				back := community & 0xffff
-				communityNames = append(communityNames, fmt.Sprintf("%d:%d", front, back))
-			}
+	// decode communities
+	if communities != nil {
+		for _, community := range *communities {
+			communityNames = append(communityNames, formatCommunity(community))
 		}
+	}
 
-		// decode large communities
-		if largeCommunities != nil {
-			for _, community := range *largeCommunities {
-				largecommunityNames = append(
-					largecommunityNames,
-					community.String(),
-				)
-			}
+	// decode large communities
+	if largeCommunities != nil {
+		for _, community := range *largeCommunities {
+			largecommunityNames = append(
+				largecommunityNames,
+				community.String(),
+			)
 		}
+	}
 
-		// partly decode extended communities
-		valid := bgp.VALIDATION_STATE_NOT_FOUND
-		if extendedCommunities != nil {
-			for _, ec := range *extendedCommunities {
-				if val, ok := ec.(*bgp.ValidationExtended); ok {
-					valid = val.State
-					break
-				}
+	// partly decode extended communities
+	valid := bgp.VALIDATION_STATE_NOT_FOUND
+	if extendedCommunities != nil {
+		for _, ec := range *extendedCommunities {
+			if val, ok := ec.(*bgp.ValidationExtended); ok {
+				valid = val.State
 			}
+			extendedCommunityNames = append(extendedCommunityNames, formatExtendedCommunity(ec))
 		}
+	}
 
-		var originValue = OriginValue(255)
-		if origin != nil {
-			originValue = OriginValue(*origin)
-		}
+	var originValue = OriginValue(255)
+	if origin != nil {
+		originValue = OriginValue(*origin)
+	}
 
-		var originAS uint32
+	var originAS uint32
+	if len(aspathNbrs) > 0 {
+		originAS = aspathNbrs[len(aspathNbrs)-1]
+	} else {
+		originAS = 0
+	}
+
+	var (
+		bogon []BogonReason
+		irr   *IRRResult
+	)
+	if prefix, err := netip.ParsePrefix(pre); err == nil {
+		bogon = r.bogons.Check(prefix, aspathNbrs)
 		if len(aspathNbrs) > 0 {
-			originAS = aspathNbrs[len(aspathNbrs)-1]
+			irr = r.irr.Validate(prefix, originAS, aspathNbrs[0])
 		} else {
-			originAS = 0
+			// locally originated, there is no neighbor
+			irr = r.irr.Validate(prefix, r.Asn, 0)
 		}
+	}
 
-		var (
-			localPrefResult uint32
-			med             uint32
-		)
-		if localPref != nil {
-			localPrefResult = *localPref
-		}
-		if multiExitDisc != nil {
-			med = *multiExitDisc
+	// validate ourselves if we have VRPs, overriding what the router sent
+	var vrps []VRP
+	if r.rpki.Count() > 0 {
+		if prefix, err := netip.ParsePrefix(pre); err == nil {
+			validationAs := originAS
+			if len(aspathNbrs) == 0 {
+				// locally originated
+				validationAs = r.Asn
+			}
+			valid, vrps = r.rpki.Validate(prefix, validationAs)
 		}
+	}
+
+	// paths learned from one of our providers are verified downstream
+	var aspa *ASPAResult
+	if r.rpki.ASPACount() > 0 && len(aspathNbrs) > 0 {
+		downstream := r.rpki.IsProvider(r.Asn, aspathNbrs[0])
+		result := r.rpki.VerifyASPA(aspathNbrs, downstream)
+		aspa = &result
+	}
 
-		result := RouteInfo{
-			AsPath:           aspathNbrs,
-			Best:             path.Best,
-			Communities:      communityNames,
-			LargeCommunities: largecommunityNames,
-			LocalPref:        localPrefResult,
-			Med:              med,
-			NextHop:          nexthopString,
-			OriginAs:         originAS,
-			Origin:           originValue,
-			Peer:             path.PeerAddress.String(),
-			Prefix:           pre,
-			Timestamp:        time.Unix(path.Age, 0),
-			Validation:       valid,
+	var (
+		localPrefResult    uint32
+		med                uint32
+		originatorIdString string
+		clusterListStrings []string
+	)
+	if originatorId != nil {
+		originatorIdString = originatorId.String()
+	}
+	if clusterList != nil {
+		for _, id := range *clusterList {
+			clusterListStrings = append(clusterListStrings, id.String())
 		}
-		results = append(results, result)
 	}
-	return results
+	if localPref != nil {
+		localPrefResult = *localPref
+	}
+	if multiExitDisc != nil {
+		med = *multiExitDisc
+	}
+
+	return RouteInfo{
+		AnnotatedCommunities: r.communities.Annotate(append(communityNames, largecommunityNames...)),
+		ASPA:                 aspa,
+		AsPath:               aspathNbrs,
+		AsPathNames:          r.asnames.Names(aspathNbrs),
+		Best:                 path.Best,
+		Bogon:                bogon,
+		ClusterList:          clusterListStrings,
+		Communities:          communityNames,
+		ExtendedCommunities:  extendedCommunityNames,
+		IRR:                  irr,
+		LargeCommunities:     largecommunityNames,
+		LocalPref:            localPrefResult,
+		Med:                  med,
+		NextHop:              nexthopString,
+		OriginAs:             originAS,
+		Origin:               originValue,
+		OriginatorId:         originatorIdString,
+		PathId:               path.RemoteID,
+		Peer:                 path.PeerAddress.String(),
+		Prefix:               pre,
+		RouterId:             path.PeerID.String(),
+		Timestamp:            time.Unix(path.Age, 0),
+		Validation:           valid,
+		VRPs:                 vrps,
+	}
 }
 
 type OriginValue uint8
@@ -437,19 +639,30 @@ func (v OriginValue) String() string {
 }
 
 type RouteInfo struct {
-	AsPath           []uint32            `json:"aspath"`
-	Best             bool                `json:"best"`
-	Communities      []string            `json:"communities"`
-	LargeCommunities []string            `json:"largecommunities"`
-	LocalPref        uint32              `json:"localpref"`
-	Med              uint32              `json:"med"`
-	NextHop          string              `json:"nexthop"`
-	OriginAs         uint32              `json:"originas"`
-	Origin           OriginValue         `json:"origin"`
-	Peer             string              `json:"peer"`
-	Prefix           string              `json:"prefix"`
-	Timestamp        time.Time           `json:"timestamp"`
-	Validation       bgp.ValidationState `json:"validation"`
+	AnnotatedCommunities []AnnotatedCommunity `json:"annotatedcommunities"`
+	ASPA                 *ASPAResult          `json:"aspa"`
+	AsPath               []uint32             `json:"aspath"`
+	AsPathNames          []asnames.Name       `json:"aspath_names"`
+	Best                 bool                 `json:"best"`
+	Bogon                []BogonReason        `json:"bogon"`
+	ClusterList          []string             `json:"clusterlist"`
+	Communities          []string             `json:"communities"`
+	ExtendedCommunities  []string             `json:"extendedcommunities"`
+	IRR                  *IRRResult           `json:"irr"`
+	LargeCommunities     []string             `json:"largecommunities"`
+	LocalPref            uint32               `json:"localpref"`
+	Med                  uint32               `json:"med"`
+	NextHop              string               `json:"nexthop"`
+	OriginAs             uint32               `json:"originas"`
+	Origin               OriginValue          `json:"origin"`
+	OriginatorId         string               `json:"originatorid"`
+	PathId               uint32               `json:"pathid"`
+	Peer                 string               `json:"peer"`
+	Prefix               string               `json:"prefix"`
+	RouterId             string               `json:"routerid"`
+	Timestamp            time.Time            `json:"timestamp"`
+	Validation           bgp.ValidationState  `json:"validation"`
+	VRPs                 []VRP                `json:"vrps"`
 }
 
 func (router *Router) Status() (bool, bool) {
@@ -466,6 +679,49 @@ func (router *Router) Status() (bool, bool) {
 	return connected, ready
 }
 
+// NeighborStatus is the state of a BGP session of a router.
+type NeighborStatus struct {
+	Address string `json:"address"`
+	Asn     uint32 `json:"asn"`
+	State   string `json:"state"`
+	// Since is when the session was established, or went down if it is not.
+	Since    time.Time `json:"since"`
+	Received uint64    `json:"received"`
+	Accepted uint64    `json:"accepted"`
+}
+
+// NeighborStatus returns the state of the sessions to all configured
+// neighbors, with the number of prefixes received from them.
+func (router *Router) NeighborStatus() []NeighborStatus {
+	var neighbors []NeighborStatus
+	for _, address := range router.Neighbors {
+		router.GobgpServer.ListPeer(context.Background(), &api.ListPeerRequest{Address: address}, func(p *api.Peer) {
+			neighbor := NeighborStatus{
+				Address: p.State.NeighborAddress,
+				Asn:     p.State.PeerAsn,
+				State:   strings.ToLower(strings.TrimPrefix(p.State.SessionState.String(), "SESSION_STATE_")),
+			}
+			if p.Timers != nil && p.Timers.State != nil {
+				since := p.Timers.State.Downtime
+				if p.State.SessionState == api.PeerState_SESSION_STATE_ESTABLISHED {
+					since = p.Timers.State.Uptime
+				}
+				if since.GetSeconds() > 0 {
+					neighbor.Since = since.AsTime()
+				}
+			}
+			for _, afiSafi := range p.AfiSafis {
+				if afiSafi.State != nil {
+					neighbor.Received += afiSafi.State.Received
+					neighbor.Accepted += afiSafi.State.Accepted
+				}
+			}
+			neighbors = append(neighbors, neighbor)
+		})
+	}
+	return neighbors
+}
+
 func (router *Router) Established() bool {
 	router.neighborSessionStateLock.Lock()
 	defer router.neighborSessionStateLock.Unlock()
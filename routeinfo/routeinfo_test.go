@@ -3,10 +3,12 @@ package routeinfo
 import (
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/BelWue/bgp_routeinfo/asnames"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"sigs.k8s.io/yaml"
@@ -102,3 +104,26 @@ func BenchmarkStatus(b *testing.B) {
 		router.Status()
 	}
 }
+
+func TestASPathNames(t *testing.T) {
+	r := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+	)
+	file := filepath.Join(t.TempDir(), "as-org2info.txt")
+	content := "# format:aut|changed|aut_name|org_id|opaque_id|source\n64500|20240101|EXAMPLE-NET|EXAMPLE-ARIN|x|ARIN\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r.asnames = &asnames.Registry{}
+	if err := r.asnames.LoadFiles(file); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []asnames.Name
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		names = paths[0].AsPathNames
+	})
+	if len(names) != 2 || names[0].Name != "EXAMPLE-NET" || names[1] != (asnames.Name{Asn: 64501}) {
+		t.Errorf("unexpected AS path names %+v", names)
+	}
+}
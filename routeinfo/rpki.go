@@ -0,0 +1,221 @@
+package routeinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// VRP is a validated ROA payload as defined in RFC 6811.
+type VRP struct {
+	Prefix    netip.Prefix `json:"prefix"`
+	MaxLength uint8        `json:"maxlength"`
+	Asn       uint32       `json:"asn"`
+}
+
+// RPKIValidator performs RPKI origin validation and ASPA verification of
+// paths. The VRPs are either received from an RTR cache (RFC 8210) or loaded
+// from a JSON export as written by rpki-client or Routinator. ASPA objects are
+// always taken from the JSON export.
+type RPKIValidator struct {
+	RTR     string `yaml:"rtr"`     // address of the RTR cache, i.e. "rtr.example.org:3323"
+	File    string `yaml:"file"`    // JSON export, only used for ASPA objects if an RTR cache is set
+	Refresh int    `yaml:"refresh"` // seconds between reloads of the file, defaults to 600
+
+	Logger log.RouteinfoLogger
+
+	lock  sync.RWMutex
+	vrps  map[netip.Prefix][]VRP
+	count int
+	aspas map[uint32][]uint32
+	stop  chan struct{}
+}
+
+// rpkiExport is the part of the rpki-client and Routinator JSON output we are
+// interested in.
+type rpkiExport struct {
+	Roas []struct {
+		Asn       jsonAsn `json:"asn"`
+		Prefix    string  `json:"prefix"`
+		MaxLength uint8   `json:"maxLength"`
+	} `json:"roas"`
+	Aspas []struct {
+		CustomerAsid jsonAsn   `json:"customer_asid"` // rpki-client
+		Customer     jsonAsn   `json:"customer"`      // Routinator
+		Providers    []jsonAsn `json:"providers"`
+	} `json:"aspas"`
+}
+
+// jsonAsn accepts ASNs as number (rpki-client) or as "AS123" string
+// (Routinator).
+type jsonAsn uint32
+
+func (a *jsonAsn) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	s = strings.TrimPrefix(strings.ToUpper(s), "AS")
+	asn, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid ASN %s", data)
+	}
+	*a = jsonAsn(asn)
+	return nil
+}
+
+// Start loads the configured VRP source and keeps it updated in the
+// background until Stop is called.
+func (v *RPKIValidator) Start() error {
+	v.stop = make(chan struct{})
+	if v.RTR != "" {
+		client := &rtrClient{address: v.RTR, validator: v}
+		go client.run(v.stop)
+	}
+	if v.File == "" {
+		if v.RTR != "" {
+			return nil
+		}
+		return fmt.Errorf("rpki: neither rtr nor file configured")
+	}
+	if err := v.LoadFile(v.File); err != nil {
+		return err
+	}
+	refresh := time.Duration(v.Refresh) * time.Second
+	if refresh <= 0 {
+		refresh = 600 * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-v.stop:
+				return
+			case <-ticker.C:
+				if err := v.LoadFile(v.File); err != nil {
+					v.logger().Errorf("Failed to reload RPKI file: %v", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (v *RPKIValidator) Stop() {
+	if v.stop != nil {
+		close(v.stop)
+		v.stop = nil
+	}
+}
+
+// LoadFile replaces the current VRPs and ASPA objects with those from a
+// rpki-client or Routinator JSON export. VRPs are left alone if they are
+// received via RTR.
+func (v *RPKIValidator) LoadFile(filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var export rpkiExport
+	if err := json.Unmarshal(content, &export); err != nil {
+		return fmt.Errorf("parsing RPKI file %s: %w", filename, err)
+	}
+	aspas := make(map[uint32][]uint32, len(export.Aspas))
+	for _, aspa := range export.Aspas {
+		customer := uint32(aspa.CustomerAsid)
+		if customer == 0 {
+			customer = uint32(aspa.Customer)
+		}
+		if _, ok := aspas[customer]; !ok {
+			// an empty provider set still attests that there is no provider
+			aspas[customer] = []uint32{}
+		}
+		for _, provider := range aspa.Providers {
+			aspas[customer] = append(aspas[customer], uint32(provider))
+		}
+	}
+	v.SetASPAs(aspas)
+	if v.RTR != "" {
+		v.logger().Infof("Loaded %d ASPA objects from %s", len(aspas), filename)
+		return nil
+	}
+
+	vrps := make([]VRP, 0, len(export.Roas))
+	for _, roa := range export.Roas {
+		prefix, err := netip.ParsePrefix(roa.Prefix)
+		if err != nil {
+			v.logger().Warnf("Skipping ROA with invalid prefix %s: %v", roa.Prefix, err)
+			continue
+		}
+		vrps = append(vrps, VRP{Prefix: prefix.Masked(), MaxLength: roa.MaxLength, Asn: uint32(roa.Asn)})
+	}
+	v.SetVRPs(vrps)
+	v.logger().Infof("Loaded %d VRPs and %d ASPA objects from %s", len(vrps), len(aspas), filename)
+	return nil
+}
+
+// SetVRPs replaces the current VRPs.
+func (v *RPKIValidator) SetVRPs(vrps []VRP) {
+	index := make(map[netip.Prefix][]VRP)
+	for _, vrp := range vrps {
+		index[vrp.Prefix] = append(index[vrp.Prefix], vrp)
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.vrps = index
+	v.count = len(vrps)
+}
+
+// Count returns the number of VRPs currently known.
+func (v *RPKIValidator) Count() int {
+	if v == nil {
+		return 0
+	}
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.count
+}
+
+// Validate returns the RFC 6811 validation state of a route and all VRPs
+// covering its prefix.
+func (v *RPKIValidator) Validate(prefix netip.Prefix, originAs uint32) (bgp.ValidationState, []VRP) {
+	covering := v.Covering(prefix)
+	if len(covering) == 0 {
+		return bgp.VALIDATION_STATE_NOT_FOUND, nil
+	}
+	for _, vrp := range covering {
+		// AS0 VRPs never match (RFC 6483)
+		if vrp.Asn != 0 && vrp.Asn == originAs && prefix.Bits() <= int(vrp.MaxLength) {
+			return bgp.VALIDATION_STATE_VALID, covering
+		}
+	}
+	return bgp.VALIDATION_STATE_INVALID, covering
+}
+
+// Covering returns all VRPs whose prefix covers the given prefix.
+func (v *RPKIValidator) Covering(prefix netip.Prefix) []VRP {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	var covering []VRP
+	for bits := 0; bits <= prefix.Bits(); bits++ {
+		p, err := prefix.Addr().Prefix(bits)
+		if err != nil {
+			break
+		}
+		covering = append(covering, v.vrps[p]...)
+	}
+	return covering
+}
+
+func (v *RPKIValidator) logger() log.ApplicationLogger {
+	if v.Logger == nil {
+		v.Logger = &log.DefaultRouteInfoLogger{}
+	}
+	return v.Logger.GetApplicationLogger()
+}
@@ -0,0 +1,172 @@
+package routeinfo
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestRPKIValidate(t *testing.T) {
+	var v RPKIValidator
+	v.SetVRPs([]VRP{
+		{Prefix: netip.MustParsePrefix("192.0.2.0/24"), MaxLength: 24, Asn: 64500},
+		{Prefix: netip.MustParsePrefix("198.51.100.0/22"), MaxLength: 24, Asn: 64501},
+		{Prefix: netip.MustParsePrefix("203.0.113.0/24"), MaxLength: 24, Asn: 0},
+		{Prefix: netip.MustParsePrefix("2001:db8::/32"), MaxLength: 48, Asn: 64502},
+	})
+
+	tests := []struct {
+		prefix   string
+		origin   uint32
+		state    bgp.ValidationState
+		covering int
+	}{
+		{"192.0.2.0/24", 64500, bgp.VALIDATION_STATE_VALID, 1},
+		{"192.0.2.0/24", 64501, bgp.VALIDATION_STATE_INVALID, 1},
+		{"192.0.2.128/25", 64500, bgp.VALIDATION_STATE_INVALID, 1},
+		{"198.51.101.0/24", 64501, bgp.VALIDATION_STATE_VALID, 1},
+		{"203.0.113.0/24", 0, bgp.VALIDATION_STATE_INVALID, 1},
+		{"2001:db8:1::/48", 64502, bgp.VALIDATION_STATE_VALID, 1},
+		{"2001:db8::/29", 64502, bgp.VALIDATION_STATE_NOT_FOUND, 0},
+		{"10.0.0.0/8", 64500, bgp.VALIDATION_STATE_NOT_FOUND, 0},
+	}
+	for _, test := range tests {
+		state, covering := v.Validate(netip.MustParsePrefix(test.prefix), test.origin)
+		if state != test.state || len(covering) != test.covering {
+			t.Errorf("Validate(%s, %d) = %s with %d VRPs; want %s with %d", test.prefix, test.origin, state, len(covering), test.state, test.covering)
+		}
+	}
+}
+
+func TestRPKILoadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "vrps.json")
+	export := `{"metadata": {}, "roas": [
+		{"asn": 64500, "prefix": "192.0.2.0/24", "maxLength": 24, "ta": "ripe"},
+		{"asn": "AS64501", "prefix": "2001:db8::/32", "maxLength": 48, "ta": "arin"}
+	]}`
+	if err := os.WriteFile(filename, []byte(export), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var v RPKIValidator
+	if err := v.LoadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+	if v.Count() != 2 {
+		t.Fatalf("loaded %d VRPs, want 2", v.Count())
+	}
+	if state, _ := v.Validate(netip.MustParsePrefix("2001:db8:ff::/48"), 64501); state != bgp.VALIDATION_STATE_VALID {
+		t.Errorf("got state %s for Routinator style ASN", state)
+	}
+}
+
+// rtrTestPdu builds an RTR version 1 PDU.
+func rtrTestPdu(pduType uint8, sessionId uint16, body []byte) []byte {
+	pdu := []byte{1, pduType, 0, 0, 0, 0, 0, 0}
+	binary.BigEndian.PutUint16(pdu[2:4], sessionId)
+	binary.BigEndian.PutUint32(pdu[4:8], uint32(8+len(body)))
+	return append(pdu, body...)
+}
+
+func rtrTestPrefix(announce bool, prefix string, maxLength uint8, asn uint32) []byte {
+	p := netip.MustParsePrefix(prefix)
+	var flags uint8
+	if announce {
+		flags = 1
+	}
+	body := []byte{flags, uint8(p.Bits()), maxLength, 0}
+	body = append(body, p.Addr().AsSlice()...)
+	body = binary.BigEndian.AppendUint32(body, asn)
+	if p.Addr().Is4() {
+		return rtrTestPdu(rtrIPv4Prefix, 0, body)
+	}
+	return rtrTestPdu(rtrIPv6Prefix, 0, body)
+}
+
+func rtrTestEndOfData(sessionId uint16, serial uint32) []byte {
+	body := binary.BigEndian.AppendUint32(nil, serial)
+	body = binary.BigEndian.AppendUint32(body, 3600)
+	body = binary.BigEndian.AppendUint32(body, 600)
+	body = binary.BigEndian.AppendUint32(body, 7200)
+	return rtrTestPdu(rtrEndOfData, sessionId, body)
+}
+
+func waitForVRPs(t *testing.T, v *RPKIValidator, count int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for v.Count() != count {
+		if time.Now().After(deadline) {
+			t.Fatalf("have %d VRPs, want %d", v.Count(), count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRPKIRTR(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	queries := make(chan []byte)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			header := make([]byte, 8)
+			if _, err := io.ReadFull(conn, header); err != nil {
+				return
+			}
+			body := make([]byte, binary.BigEndian.Uint32(header[4:8])-8)
+			if _, err := io.ReadFull(conn, body); err != nil {
+				return
+			}
+			queries <- header
+			switch header[1] {
+			case rtrResetQuery:
+				conn.Write(rtrTestPdu(rtrCacheResponse, 42, nil))
+				conn.Write(rtrTestPrefix(true, "192.0.2.0/24", 24, 64500))
+				conn.Write(rtrTestPrefix(true, "198.51.100.0/24", 24, 64501))
+				conn.Write(rtrTestPrefix(true, "2001:db8::/32", 48, 64502))
+				conn.Write(rtrTestEndOfData(42, 1))
+				// announce a new serial right away
+				conn.Write(rtrTestPdu(rtrSerialNotify, 42, binary.BigEndian.AppendUint32(nil, 2)))
+			case rtrSerialQuery:
+				conn.Write(rtrTestPdu(rtrCacheResponse, 42, nil))
+				conn.Write(rtrTestPrefix(false, "198.51.100.0/24", 24, 64501))
+				conn.Write(rtrTestEndOfData(42, 2))
+			}
+		}
+	}()
+
+	v := &RPKIValidator{RTR: listener.Addr().String()}
+	if err := v.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	if q := <-queries; q[1] != rtrResetQuery || q[0] != 1 {
+		t.Fatalf("expected version 1 reset query, got %v", q)
+	}
+	if q := <-queries; q[1] != rtrSerialQuery || binary.BigEndian.Uint16(q[2:4]) != 42 {
+		t.Fatalf("expected serial query for session 42, got %v", q)
+	}
+	waitForVRPs(t, v, 2)
+
+	if state, _ := v.Validate(netip.MustParsePrefix("198.51.100.0/24"), 64501); state != bgp.VALIDATION_STATE_NOT_FOUND {
+		t.Errorf("withdrawn VRP still in use, got state %s", state)
+	}
+	if state, vrps := v.Validate(netip.MustParsePrefix("192.0.2.0/24"), 64500); state != bgp.VALIDATION_STATE_VALID || len(vrps) != 1 {
+		t.Errorf("got state %s with %v", state, vrps)
+	}
+}
@@ -0,0 +1,245 @@
+package routeinfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// RTR PDU types, RFC 8210 section 5
+const (
+	rtrSerialNotify  uint8 = 0
+	rtrSerialQuery   uint8 = 1
+	rtrResetQuery    uint8 = 2
+	rtrCacheResponse uint8 = 3
+	rtrIPv4Prefix    uint8 = 4
+	rtrIPv6Prefix    uint8 = 6
+	rtrEndOfData     uint8 = 7
+	rtrCacheReset    uint8 = 8
+	rtrRouterKey     uint8 = 9
+	rtrErrorReport   uint8 = 10
+
+	rtrErrUnsupportedVersion uint16 = 4
+	rtrMaxPduLength                 = 65536
+)
+
+type rtrPdu struct {
+	version   uint8
+	pduType   uint8
+	sessionId uint16
+	body      []byte
+}
+
+// rtrClient keeps the VRPs of an RPKIValidator in sync with an RTR cache.
+type rtrClient struct {
+	address   string
+	validator *RPKIValidator
+
+	version   uint8
+	sessionId uint16
+	serial    uint32
+	synced    bool
+	refresh   time.Duration
+	retry     time.Duration
+	vrps      map[VRP]struct{}
+}
+
+func (c *rtrClient) run(stop chan struct{}) {
+	c.version = 1
+	c.refresh = 3600 * time.Second
+	c.retry = 600 * time.Second
+	for {
+		err := c.session(stop)
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		retry := c.retry
+		if errors.Is(err, errRtrDowngrade) {
+			retry = 0
+		}
+		c.validator.logger().Warnf("RTR session to %s ended: %v, reconnecting in %s", c.address, err, retry)
+		select {
+		case <-stop:
+			return
+		case <-time.After(retry):
+		}
+	}
+}
+
+var errRtrDowngrade = errors.New("cache does not support RTR version 1, falling back to version 0")
+
+func (c *rtrClient) session(stop chan struct{}) error {
+	conn, err := net.DialTimeout("tcp", c.address, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		conn.Close()
+	}()
+	c.validator.logger().Infof("Connected to RTR cache %s (version %d)", c.address, c.version)
+
+	if c.synced {
+		err = c.write(conn, rtrSerialQuery, c.sessionId, binary.BigEndian.AppendUint32(nil, c.serial))
+	} else {
+		err = c.write(conn, rtrResetQuery, 0, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// VRPs of the response currently being received
+	var pending map[VRP]struct{}
+	for {
+		conn.SetReadDeadline(time.Now().Add(c.refresh))
+		pdu, err := c.read(conn)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() && pending == nil && c.synced {
+				// refresh interval passed without notify
+				if err := c.write(conn, rtrSerialQuery, c.sessionId, binary.BigEndian.AppendUint32(nil, c.serial)); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		switch pdu.pduType {
+		case rtrSerialNotify:
+			if pending == nil && c.synced {
+				if err := c.write(conn, rtrSerialQuery, c.sessionId, binary.BigEndian.AppendUint32(nil, c.serial)); err != nil {
+					return err
+				}
+			}
+		case rtrCacheResponse:
+			if c.synced && pdu.sessionId == c.sessionId {
+				pending = make(map[VRP]struct{}, len(c.vrps))
+				for vrp := range c.vrps {
+					pending[vrp] = struct{}{}
+				}
+			} else {
+				pending = make(map[VRP]struct{})
+			}
+			c.sessionId = pdu.sessionId
+		case rtrIPv4Prefix, rtrIPv6Prefix:
+			if pending == nil {
+				return fmt.Errorf("received prefix outside of cache response")
+			}
+			announce, vrp, err := parseRtrPrefix(pdu)
+			if err != nil {
+				return err
+			}
+			if announce {
+				pending[vrp] = struct{}{}
+			} else {
+				delete(pending, vrp)
+			}
+		case rtrEndOfData:
+			if pending == nil {
+				return fmt.Errorf("received end of data outside of cache response")
+			}
+			if len(pdu.body) < 4 {
+				return fmt.Errorf("short end of data PDU")
+			}
+			c.serial = binary.BigEndian.Uint32(pdu.body[0:4])
+			if pdu.version >= 1 && len(pdu.body) >= 16 {
+				if refresh := binary.BigEndian.Uint32(pdu.body[4:8]); refresh > 0 {
+					c.refresh = time.Duration(refresh) * time.Second
+				}
+				if retry := binary.BigEndian.Uint32(pdu.body[8:12]); retry > 0 {
+					c.retry = time.Duration(retry) * time.Second
+				}
+			}
+			c.vrps = pending
+			c.synced = true
+			pending = nil
+			vrps := make([]VRP, 0, len(c.vrps))
+			for vrp := range c.vrps {
+				vrps = append(vrps, vrp)
+			}
+			c.validator.SetVRPs(vrps)
+			c.validator.logger().Debugf("RTR cache %s at serial %d, %d VRPs", c.address, c.serial, len(vrps))
+		case rtrCacheReset:
+			c.synced = false
+			pending = nil
+			if err := c.write(conn, rtrResetQuery, 0, nil); err != nil {
+				return err
+			}
+		case rtrRouterKey:
+			// BGPsec is not of interest here
+		case rtrErrorReport:
+			if pdu.sessionId == rtrErrUnsupportedVersion && c.version > 0 {
+				c.version = 0
+				return errRtrDowngrade
+			}
+			return fmt.Errorf("cache reported error %d", pdu.sessionId)
+		default:
+			c.validator.logger().Debugf("Ignoring RTR PDU of type %d", pdu.pduType)
+		}
+	}
+}
+
+func (c *rtrClient) write(conn net.Conn, pduType uint8, sessionId uint16, body []byte) error {
+	buf := make([]byte, 8, 8+len(body))
+	buf[0] = c.version
+	buf[1] = pduType
+	binary.BigEndian.PutUint16(buf[2:4], sessionId)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(8+len(body)))
+	buf = append(buf, body...)
+	_, err := conn.Write(buf)
+	return err
+}
+
+func (c *rtrClient) read(conn net.Conn) (*rtrPdu, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length < 8 || length > rtrMaxPduLength {
+		return nil, fmt.Errorf("invalid RTR PDU length %d", length)
+	}
+	pdu := &rtrPdu{
+		version:   header[0],
+		pduType:   header[1],
+		sessionId: binary.BigEndian.Uint16(header[2:4]),
+		body:      make([]byte, length-8),
+	}
+	if _, err := io.ReadFull(conn, pdu.body); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+func parseRtrPrefix(pdu *rtrPdu) (bool, VRP, error) {
+	addrLen := 4
+	if pdu.pduType == rtrIPv6Prefix {
+		addrLen = 16
+	}
+	if len(pdu.body) != 4+addrLen+4 {
+		return false, VRP{}, fmt.Errorf("invalid prefix PDU length %d", len(pdu.body)+8)
+	}
+	announce := pdu.body[0]&1 == 1
+	addr, _ := netip.AddrFromSlice(pdu.body[4 : 4+addrLen])
+	prefix, err := addr.Prefix(int(pdu.body[1]))
+	if err != nil {
+		return false, VRP{}, err
+	}
+	return announce, VRP{
+		Prefix:    prefix,
+		MaxLength: pdu.body[2],
+		Asn:       binary.BigEndian.Uint32(pdu.body[4+addrLen:]),
+	}, nil
+}
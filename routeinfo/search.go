@@ -0,0 +1,172 @@
+package routeinfo
+
+import (
+	"cmp"
+	"container/heap"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ASPathRegexp is a compiled AS path regular expression in the syntax used by
+// Cisco and Juniper. The AS path is matched as a string of AS numbers
+// separated by single spaces, where '_' matches the start or end of the path
+// or a delimiter, and " .* " also matches zero ASes in between. Everything
+// else is a regular expression as understood by the regexp package, i.e.
+// "_3320_", "^174 .* 553$" or "^(174|3356)_".
+type ASPathRegexp struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+func CompileASPathRegexp(expr string) (*ASPathRegexp, error) {
+	translated := strings.ReplaceAll(expr, " .* ", " (?:.* )?")
+	translated = strings.ReplaceAll(translated, "_", `(?:^|[ ,{}()]|$)`)
+	re, err := regexp.Compile(translated)
+	if err != nil {
+		return nil, err
+	}
+	return &ASPathRegexp{expr: expr, re: re}, nil
+}
+
+func (a *ASPathRegexp) String() string {
+	return a.expr
+}
+
+// MatchASPath reports whether the AS path matches.
+func (a *ASPathRegexp) MatchASPath(aspath []uint32) bool {
+	return a.re.MatchString(formatASPath(aspath))
+}
+
+func formatASPath(aspath []uint32) string {
+	var b strings.Builder
+	for i, asn := range aspath {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.FormatUint(uint64(asn), 10))
+	}
+	return b.String()
+}
+
+// ComparePaths orders paths by prefix, peer and path ID, which identify a
+// path in the RIB of a router.
+func ComparePaths(a, b RouteInfo) int {
+	prefixA, errA := netip.ParsePrefix(a.Prefix)
+	prefixB, errB := netip.ParsePrefix(b.Prefix)
+	if errA != nil || errB != nil {
+		if c := strings.Compare(a.Prefix, b.Prefix); c != 0 {
+			return c
+		}
+	} else {
+		if c := prefixA.Addr().Compare(prefixB.Addr()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(prefixA.Bits(), prefixB.Bits()); c != 0 {
+			return c
+		}
+	}
+	if c := compareAddrStrings(a.Peer, b.Peer); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Peer, b.Peer); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.PathId, b.PathId)
+}
+
+// PathWindow keeps the paths at offset up to offset+limit of all paths added
+// to it in the order of ComparePaths, so a page of search results does not
+// need all matching paths in memory.
+type PathWindow struct {
+	Offset int
+	Limit  int
+	// Total is the number of paths added.
+	Total int
+	paths pathHeap
+}
+
+func NewPathWindow(offset int, limit int) *PathWindow {
+	return &PathWindow{Offset: offset, Limit: limit}
+}
+
+// Add adds a path to the window, it is dropped if there are already
+// offset+limit lower paths.
+func (w *PathWindow) Add(path RouteInfo) {
+	w.Total++
+	if len(w.paths) < w.Offset+w.Limit {
+		heap.Push(&w.paths, path)
+	} else if len(w.paths) > 0 && ComparePaths(path, w.paths[0]) < 0 {
+		w.paths[0] = path
+		heap.Fix(&w.paths, 0)
+	}
+}
+
+// Paths returns the paths in the window in order.
+func (w *PathWindow) Paths() []RouteInfo {
+	paths := slices.Clone(w.paths)
+	slices.SortFunc(paths, ComparePaths)
+	if w.Offset >= len(paths) {
+		return nil
+	}
+	return paths[w.Offset:]
+}
+
+// pathHeap is a max-heap of paths in the order of ComparePaths.
+type pathHeap []RouteInfo
+
+func (h pathHeap) Len() int           { return len(h) }
+func (h pathHeap) Less(i, j int) bool { return ComparePaths(h[i], h[j]) > 0 }
+func (h pathHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *pathHeap) Push(x any)        { *h = append(*h, x.(RouteInfo)) }
+
+func (h *pathHeap) Pop() any {
+	old := *h
+	path := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return path
+}
+
+// SearchASPath calls fn with all paths in the RIB whose AS path matches the AS
+// path regular expression, see ASPathRegexp for the syntax.
+func (r *Router) SearchASPath(expr string, fn func(path RouteInfo)) error {
+	re, err := CompileASPathRegexp(expr)
+	if err != nil {
+		return err
+	}
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		for _, path := range paths {
+			if re.MatchASPath(path.AsPath) {
+				fn(path)
+			}
+		}
+	})
+	return nil
+}
+
+// SearchNextHop calls fn with all paths in the RIB resolving via the next hop.
+func (r *Router) SearchNextHop(address string, fn func(path RouteInfo)) error {
+	return r.searchAddress(address, func(path *RouteInfo) string { return path.NextHop }, fn)
+}
+
+// SearchPeer calls fn with all paths in the RIB received from the neighbor.
+func (r *Router) SearchPeer(address string, fn func(path RouteInfo)) error {
+	return r.searchAddress(address, func(path *RouteInfo) string { return path.Peer }, fn)
+}
+
+func (r *Router) searchAddress(address string, field func(path *RouteInfo) string, fn func(path RouteInfo)) error {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return err
+	}
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		for _, path := range paths {
+			if pathAddr, err := netip.ParseAddr(field(&path)); err == nil && pathAddr.Unmap() == addr.Unmap() {
+				fn(path)
+			}
+		}
+	})
+	return nil
+}
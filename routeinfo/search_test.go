@@ -0,0 +1,111 @@
+package routeinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestASPathRegexp(t *testing.T) {
+	tests := []struct {
+		expr   string
+		aspath []uint32
+		match  bool
+	}{
+		{"_3320_", []uint32{174, 3320, 553}, true},
+		{"_3320_", []uint32{174, 33200, 553}, false},
+		{"_3320_", []uint32{3320}, true},
+		{"^174 .* 553$", []uint32{174, 3320, 1299, 553}, true},
+		{"^174 .* 553$", []uint32{174, 553}, true},
+		{"^174 .* 553$", []uint32{174, 553, 64500}, false},
+		{"^174_", []uint32{174, 553}, true},
+		{"^174_", []uint32{1740, 553}, false},
+		{"^(174|3356)_", []uint32{3356, 553}, true},
+		{"_553$", []uint32{174, 553}, true},
+		{"^$", nil, true},
+		{"^$", []uint32{553}, false},
+		{"^[0-9]+$", []uint32{553}, true},
+		{"^[0-9]+$", []uint32{174, 553}, false},
+	}
+	for _, test := range tests {
+		re, err := CompileASPathRegexp(test.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", test.expr, err)
+		}
+		if match := re.MatchASPath(test.aspath); match != test.match {
+			t.Errorf("%s on %v: got %t, want %t", test.expr, test.aspath, match, test.match)
+		}
+	}
+
+	if _, err := CompileASPathRegexp("(174"); err == nil {
+		t.Error("invalid expression compiled")
+	}
+}
+
+func TestSearchASPath(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{3320, 64501}, localPref: 100},
+		testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{174, 64502}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{174, 3320, 64501}, localPref: 100},
+	)
+	window := NewPathWindow(0, 10)
+	if err := router.SearchASPath("_3320_", window.Add); err != nil {
+		t.Fatal(err)
+	}
+	if paths := window.Paths(); len(paths) != 2 || paths[0].Prefix != "192.0.2.0/24" || paths[1].Prefix != "2001:db8::/32" {
+		t.Errorf("unexpected paths %+v", paths)
+	}
+}
+
+func TestSearchNextHop(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500}, localPref: 100},
+		testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.2", aspath: []uint32{64501}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64500}, localPref: 100},
+	)
+	window := NewPathWindow(0, 10)
+	if err := router.SearchNextHop("198.51.100.1", window.Add); err != nil {
+		t.Fatal(err)
+	}
+	if paths := window.Paths(); len(paths) != 1 || paths[0].Prefix != "192.0.2.0/24" {
+		t.Errorf("unexpected paths %+v", paths)
+	}
+	window = NewPathWindow(0, 10)
+	if err := router.SearchNextHop("2001:db8:ffff:0::1", window.Add); err != nil {
+		t.Fatal(err)
+	}
+	if paths := window.Paths(); len(paths) != 1 || paths[0].Prefix != "2001:db8::/32" {
+		t.Errorf("unexpected paths %+v", paths)
+	}
+	if err := router.SearchPeer("not-an-address", func(RouteInfo) {}); err == nil {
+		t.Error("invalid address did not fail")
+	}
+}
+
+func TestPathWindow(t *testing.T) {
+	paths := []RouteInfo{
+		{Prefix: "192.0.2.0/24", Peer: "198.51.100.2"},
+		{Prefix: "2001:db8::/32", Peer: "2001:db8:ffff::1"},
+		{Prefix: "192.0.2.0/24", Peer: "198.51.100.1", PathId: 2},
+		{Prefix: "192.0.2.0/23", Peer: "198.51.100.1"},
+		{Prefix: "10.0.0.0/8", Peer: "198.51.100.1"},
+		{Prefix: "192.0.2.0/24", Peer: "198.51.100.1", PathId: 1},
+	}
+	want := []RouteInfo{paths[4], paths[3], paths[5], paths[2], paths[0], paths[1]}
+	for offset := 0; offset <= len(paths); offset++ {
+		for limit := 1; limit <= len(paths); limit++ {
+			// rotate the order the paths are added in
+			window := NewPathWindow(offset, limit)
+			for i := range paths {
+				window.Add(paths[(i+offset+limit)%len(paths)])
+			}
+			got := window.Paths()
+			expected := want[min(offset, len(want)):min(offset+limit, len(want))]
+			if len(expected) == 0 {
+				expected = nil
+			}
+			if window.Total != len(paths) || !reflect.DeepEqual(got, expected) {
+				t.Errorf("offset %d limit %d: got %d paths %v, want %v", offset, limit, window.Total, got, expected)
+			}
+		}
+	}
+}
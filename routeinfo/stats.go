@@ -0,0 +1,99 @@
+package routeinfo
+
+import (
+	"cmp"
+	"net/netip"
+	"slices"
+)
+
+// statsTopOrigins is the number of origin ASes reported in TableStats.
+const statsTopOrigins = 20
+
+// OriginCount is the number of prefixes originated by an AS.
+type OriginCount struct {
+	Asn      uint32 `json:"asn"`
+	Prefixes int    `json:"prefixes"`
+}
+
+// TableStats summarizes the RIB of a router. Origin AS and RPKI state are
+// counted per prefix using its best path, everything else per path.
+type TableStats struct {
+	IPv4Prefixes        int            `json:"ipv4prefixes"`
+	IPv6Prefixes        int            `json:"ipv6prefixes"`
+	IPv4PrefixLengths   map[int]int    `json:"ipv4prefixlengths"`
+	IPv6PrefixLengths   map[int]int    `json:"ipv6prefixlengths"`
+	Paths               int            `json:"paths"`
+	BestPaths           int            `json:"bestpaths"`
+	BackupPaths         int            `json:"backuppaths"`
+	Peers               map[string]int `json:"peers"`
+	TopOrigins          []OriginCount  `json:"toporigins"`
+	UniqueASPaths       int            `json:"uniqueaspaths"`
+	AverageASPathLength float64        `json:"averageaspathlength"`
+	RPKI                map[string]int `json:"rpki"`
+}
+
+// Stats walks the RIB and returns its statistics.
+func (r *Router) Stats() TableStats {
+	stats := TableStats{
+		IPv4PrefixLengths: make(map[int]int),
+		IPv6PrefixLengths: make(map[int]int),
+		Peers:             make(map[string]int),
+		RPKI:              make(map[string]int),
+	}
+	origins := make(map[uint32]int)
+	aspaths := make(map[string]struct{})
+	aspathLengths := 0
+
+	r.Walk(func(prefix string, paths []RouteInfo) {
+		if len(paths) == 0 {
+			return
+		}
+		bits := -1
+		if p, err := netip.ParsePrefix(prefix); err == nil {
+			bits = p.Bits()
+		}
+		if isIPv6Prefix(prefix) {
+			stats.IPv6Prefixes++
+			stats.IPv6PrefixLengths[bits]++
+		} else {
+			stats.IPv4Prefixes++
+			stats.IPv4PrefixLengths[bits]++
+		}
+
+		best := &paths[0]
+		for i, path := range paths {
+			stats.Paths++
+			if path.Best {
+				stats.BestPaths++
+				best = &paths[i]
+			} else {
+				stats.BackupPaths++
+			}
+			stats.Peers[path.Peer]++
+			aspaths[formatASPath(path.AsPath)] = struct{}{}
+			aspathLengths += len(path.AsPath)
+		}
+		if len(best.AsPath) > 0 {
+			origins[best.OriginAs]++
+		}
+		stats.RPKI[best.Validation.String()]++
+	})
+
+	stats.UniqueASPaths = len(aspaths)
+	if stats.Paths > 0 {
+		stats.AverageASPathLength = float64(aspathLengths) / float64(stats.Paths)
+	}
+	for asn, prefixes := range origins {
+		stats.TopOrigins = append(stats.TopOrigins, OriginCount{Asn: asn, Prefixes: prefixes})
+	}
+	slices.SortFunc(stats.TopOrigins, func(a, b OriginCount) int {
+		if c := cmp.Compare(b.Prefixes, a.Prefixes); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Asn, b.Asn)
+	})
+	if len(stats.TopOrigins) > statsTopOrigins {
+		stats.TopOrigins = stats.TopOrigins[:statsTopOrigins]
+	}
+	return stats
+}
@@ -0,0 +1,33 @@
+package routeinfo
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	router := newTestRouter(t,
+		testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "198.51.100.0/25", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}, localPref: 100},
+		testRoute{prefix: "203.0.113.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64502, 64503}, localPref: 100},
+		testRoute{prefix: "2001:db8::/32", nexthop: "2001:db8:ffff::1", aspath: []uint32{64502}, localPref: 100},
+	)
+
+	stats := router.Stats()
+	if stats.IPv4Prefixes != 3 || stats.IPv6Prefixes != 1 || stats.Paths != 4 {
+		t.Errorf("got %d IPv4 and %d IPv6 prefixes with %d paths", stats.IPv4Prefixes, stats.IPv6Prefixes, stats.Paths)
+	}
+	if stats.IPv4PrefixLengths[24] != 2 || stats.IPv4PrefixLengths[25] != 1 || stats.IPv6PrefixLengths[32] != 1 {
+		t.Errorf("unexpected prefix lengths %v %v", stats.IPv4PrefixLengths, stats.IPv6PrefixLengths)
+	}
+	if stats.BestPaths != 4 || stats.BackupPaths != 0 {
+		t.Errorf("got %d best and %d backup paths", stats.BestPaths, stats.BackupPaths)
+	}
+	if stats.UniqueASPaths != 3 || stats.AverageASPathLength != 2 {
+		t.Errorf("got %d unique AS paths of average length %f", stats.UniqueASPaths, stats.AverageASPathLength)
+	}
+	if len(stats.TopOrigins) != 3 || stats.TopOrigins[0] != (OriginCount{Asn: 64501, Prefixes: 2}) ||
+		stats.TopOrigins[1] != (OriginCount{Asn: 64502, Prefixes: 1}) {
+		t.Errorf("unexpected top origins %+v", stats.TopOrigins)
+	}
+	if stats.RPKI["not-found"] != 4 {
+		t.Errorf("unexpected RPKI states %v", stats.RPKI)
+	}
+}
@@ -0,0 +1,74 @@
+package routeinfo
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// bestPathWatcher follows the best paths of watched prefixes on a router and
+// raises events when they change, are withdrawn or become RPKI invalid.
+type bestPathWatcher struct {
+	router   *Router
+	prefixes map[string]bool
+
+	lock sync.Mutex
+	// best paths of all watched prefixes seen so far, nil after withdrawal
+	best map[string]*RouteInfo
+}
+
+func newBestPathWatcher(router *Router, prefixes []netip.Prefix) *bestPathWatcher {
+	w := &bestPathWatcher{
+		router:   router,
+		prefixes: make(map[string]bool),
+		best:     make(map[string]*RouteInfo),
+	}
+	for _, prefix := range prefixes {
+		w.prefixes[prefix.Masked().String()] = true
+	}
+	return w
+}
+
+// update processes best paths as reported by the BGP server. A withdrawn path
+// means the prefix has no best path anymore.
+func (w *bestPathWatcher) update(paths []*apiutil.Path) {
+	if w == nil {
+		return
+	}
+	for _, path := range paths {
+		if path == nil || path.Nlri == nil || !w.prefixes[path.Nlri.String()] {
+			continue
+		}
+		prefix := path.Nlri.String()
+		var info *RouteInfo
+		if !path.Withdrawal {
+			routeInfo := w.router.routeInfoFromPath(prefix, path)
+			info = &routeInfo
+		}
+
+		w.lock.Lock()
+		previous, seen := w.best[prefix]
+		w.best[prefix] = info
+		w.lock.Unlock()
+
+		switch {
+		case info == nil && previous != nil:
+			event := w.router.newEvent(EventWithdrawn, nil, fmt.Sprintf("%s was withdrawn", prefix))
+			event.Prefix = prefix
+			w.router.raise(event)
+		case info != nil && previous == nil && seen:
+			w.router.raise(w.router.newEvent(EventBestPathChanged, info, fmt.Sprintf("%s was announced again via %s", prefix, info.NextHop)))
+		case info != nil && previous != nil:
+			if differences := bestPathDifferences(previous, info); len(differences) > 0 {
+				w.router.raise(w.router.newEvent(EventBestPathChanged, info, fmt.Sprintf("best path of %s changed (%s), now via %s", prefix, strings.Join(differences, ", "), info.NextHop)))
+			}
+		}
+		if info != nil && info.Validation == bgp.VALIDATION_STATE_INVALID && (previous == nil || previous.Validation != bgp.VALIDATION_STATE_INVALID) {
+			w.router.raise(w.router.newEvent(EventRPKIInvalid, info, fmt.Sprintf("best path of %s originated by AS%d is RPKI invalid", prefix, info.OriginAs)))
+		}
+	}
+}
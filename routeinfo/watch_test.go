@@ -0,0 +1,61 @@
+package routeinfo
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+)
+
+func TestBestPathWatcher(t *testing.T) {
+	var server RouteInfoServer
+	events, unsubscribe := server.Subscribe(10)
+	defer unsubscribe()
+	router := &Router{Name: "test", Asn: 64496, Logger: &log.DefaultRouteInfoLogger{}, events: server.eventStream()}
+	rpki := &RPKIValidator{}
+	rpki.SetVRPs([]VRP{{Prefix: netip.MustParsePrefix("192.0.2.0/24"), MaxLength: 24, Asn: 64501}})
+	router.rpki = rpki
+	watcher := newBestPathWatcher(router, []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")})
+
+	expect := func(want ...EventType) {
+		t.Helper()
+		for _, eventType := range want {
+			select {
+			case event := <-events:
+				if event.Type != eventType {
+					t.Errorf("got %s event (%s), want %s", event.Type, event.Message, eventType)
+				}
+			default:
+				t.Errorf("missing %s event", eventType)
+			}
+		}
+		select {
+		case event := <-events:
+			t.Errorf("unexpected %s event: %s", event.Type, event.Message)
+		default:
+		}
+	}
+	best := func(route testRoute, withdrawal bool) {
+		path := newTestPath(t, route)
+		path.Best = true
+		path.Withdrawal = withdrawal
+		watcher.update([]*apiutil.Path{path})
+	}
+
+	best(testRoute{prefix: "198.51.100.0/24", nexthop: "198.51.100.1", aspath: []uint32{64666}}, false)
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}}, false)
+	expect()
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}}, false)
+	expect()
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.2", aspath: []uint32{64502, 64501}}, false)
+	expect(EventBestPathChanged)
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.2", aspath: []uint32{64502, 64666}}, false)
+	expect(EventBestPathChanged, EventRPKIInvalid)
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.2"}, true)
+	expect(EventWithdrawn)
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.2"}, true)
+	expect()
+	best(testRoute{prefix: "192.0.2.0/24", nexthop: "198.51.100.1", aspath: []uint32{64500, 64501}}, false)
+	expect(EventBestPathChanged)
+}
@@ -0,0 +1,150 @@
+package routeinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+)
+
+// Webhook POSTs events to a URL. The body is the event as JSON, or the
+// rendered Template if one is given. Templates use the text/template syntax
+// with the Event as data and a "json" function for quoting, i.e.
+//
+//	{"text": {{json (printf "%s: %s" .Router .Message)}}}
+//
+// for Slack or Mattermost.
+type Webhook struct {
+	URL      string `yaml:"url"`
+	Template string `yaml:"template"`
+	// Events are the types of events to send, all if empty.
+	Events []EventType `yaml:"events"`
+	// Retries is the number of retries of failed requests, defaults to 3.
+	Retries *int `yaml:"retries"`
+	// RateLimit is the maximum number of events sent per minute, defaults to
+	// 30. Events above are dropped.
+	RateLimit int `yaml:"ratelimit"`
+	// Timeout is the timeout of each request in seconds, defaults to 10.
+	Timeout int `yaml:"timeout"`
+
+	template   *template.Template
+	client     *http.Client
+	retryDelay time.Duration
+	lock       sync.Mutex
+	sent       []time.Time
+}
+
+func (w *Webhook) init() error {
+	if w.URL == "" {
+		return fmt.Errorf("webhook without url")
+	}
+	if w.Template != "" {
+		t, err := template.New(w.URL).Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+		}).Parse(w.Template)
+		if err != nil {
+			return err
+		}
+		w.template = t
+	}
+	if w.Retries == nil {
+		retries := 3
+		w.Retries = &retries
+	}
+	if w.RateLimit == 0 {
+		w.RateLimit = 30
+	}
+	if w.Timeout == 0 {
+		w.Timeout = 10
+	}
+	if w.retryDelay == 0 {
+		w.retryDelay = time.Second
+	}
+	w.client = &http.Client{Timeout: time.Duration(w.Timeout) * time.Second}
+	return nil
+}
+
+// wants reports whether events of the type are sent.
+func (w *Webhook) wants(eventType EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// allow reports whether another event may be sent at the given time.
+func (w *Webhook) allow(now time.Time) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.sent = slices.DeleteFunc(w.sent, func(t time.Time) bool {
+		return now.Sub(t) >= time.Minute
+	})
+	if len(w.sent) >= w.RateLimit {
+		return false
+	}
+	w.sent = append(w.sent, now)
+	return true
+}
+
+func (w *Webhook) body(event Event) ([]byte, error) {
+	if w.template == nil {
+		return json.Marshal(event)
+	}
+	var b bytes.Buffer
+	if err := w.template.Execute(&b, event); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Notify sends an event, retrying with exponential backoff on errors and
+// unsuccessful responses.
+func (w *Webhook) Notify(event Event) error {
+	body, err := w.body(event)
+	if err != nil {
+		return err
+	}
+	delay := w.retryDelay
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil || attempt >= *w.Retries {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (w *Webhook) post(body []byte) error {
+	response, err := w.client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", w.URL, response.Status)
+	}
+	return nil
+}
+
+// run sends all wanted events until the channel is closed.
+func (w *Webhook) run(events <-chan Event, logger log.RouteinfoLogger) {
+	for event := range events {
+		if !w.wants(event.Type) {
+			continue
+		}
+		if !w.allow(time.Now()) {
+			logger.GetApplicationLogger().Warnf("Rate limit of webhook %s exceeded, dropping %s event", w.URL, event.Type)
+			continue
+		}
+		if err := w.Notify(event); err != nil {
+			logger.GetApplicationLogger().Errorf("Failed to send %s event to webhook: %v", event.Type, err)
+		}
+	}
+}
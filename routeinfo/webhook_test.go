@@ -0,0 +1,78 @@
+package routeinfo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BelWue/bgp_routeinfo/log"
+)
+
+func TestWebhook(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		bodies []string
+		fail   = 2
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if fail > 0 {
+			fail--
+			http.Error(writer, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(request.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	webhook := &Webhook{
+		URL:        server.URL,
+		Template:   `{"text": {{json (printf "%s: %s" .Router .Message)}}}`,
+		Events:     []EventType{EventNeighborDown},
+		retryDelay: time.Millisecond,
+	}
+	if err := webhook.init(); err != nil {
+		t.Fatal(err)
+	}
+	event := Event{Type: EventNeighborDown, Router: "rt-1", Message: `peer "192.0.2.1" down`}
+	if err := webhook.Notify(event); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 1 || bodies[0] != `{"text": "rt-1: peer \"192.0.2.1\" down"}` {
+		t.Errorf("unexpected bodies %q", bodies)
+	}
+
+	// retries exhausted
+	fail = 4
+	if err := webhook.Notify(event); err == nil {
+		t.Error("failing webhook did not return an error")
+	}
+	fail = 0
+
+	// filtered and rate limited events
+	webhook.RateLimit = 2
+	webhook.sent = nil
+	events := make(chan Event, 10)
+	events <- Event{Type: EventWithdrawn}
+	for range 3 {
+		events <- event
+	}
+	close(events)
+	logger := &log.DefaultRouteInfoLogger{}
+	webhook.run(events, logger)
+	if len(bodies) != 3 {
+		t.Errorf("got %d bodies, want 3", len(bodies))
+	}
+	if webhook.allow(time.Now().Add(time.Minute)) != true {
+		t.Error("rate limit not reset after a minute")
+	}
+
+	if err := (&Webhook{URL: server.URL, Template: "{{"}).init(); err == nil {
+		t.Error("invalid template accepted")
+	}
+}